@@ -0,0 +1,287 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/evilmonkeyinc/golang-cli/errors"
+	"github.com/evilmonkeyinc/golang-cli/flags"
+	"github.com/evilmonkeyinc/golang-cli/shell"
+)
+
+// ArgType is the type of a positional argument value.
+type ArgType int
+
+const (
+	// ArgString is a string argument, which is the default argument type.
+	ArgString ArgType = iota
+	// ArgInt is an int64 argument.
+	ArgInt
+	// ArgUint is a uint64 argument.
+	ArgUint
+	// ArgFloat is a float64 argument.
+	ArgFloat
+	// ArgBool is a bool argument.
+	ArgBool
+	// ArgDuration is a time.Duration argument.
+	ArgDuration
+)
+
+// parse converts the argument to a value of the argument type.
+func (argType ArgType) parse(value string) (interface{}, error) {
+	switch argType {
+	case ArgInt:
+		return strconv.ParseInt(value, 0, 64)
+	case ArgUint:
+		return strconv.ParseUint(value, 0, 64)
+	case ArgFloat:
+		return strconv.ParseFloat(value, 64)
+	case ArgBool:
+		return strconv.ParseBool(value)
+	case ArgDuration:
+		return time.ParseDuration(value)
+	}
+	return value, nil
+}
+
+// slice returns the values of a variadic argument as a slice of the argument type.
+func (argType ArgType) slice(values []interface{}) interface{} {
+	switch argType {
+	case ArgInt:
+		slice := make([]int64, len(values))
+		for i, value := range values {
+			slice[i] = value.(int64)
+		}
+		return slice
+	case ArgUint:
+		slice := make([]uint64, len(values))
+		for i, value := range values {
+			slice[i] = value.(uint64)
+		}
+		return slice
+	case ArgFloat:
+		slice := make([]float64, len(values))
+		for i, value := range values {
+			slice[i] = value.(float64)
+		}
+		return slice
+	case ArgBool:
+		slice := make([]bool, len(values))
+		for i, value := range values {
+			slice[i] = value.(bool)
+		}
+		return slice
+	case ArgDuration:
+		slice := make([]time.Duration, len(values))
+		for i, value := range values {
+			slice[i] = value.(time.Duration)
+		}
+		return slice
+	}
+	slice := make([]string, len(values))
+	for i, value := range values {
+		slice[i] = value.(string)
+	}
+	return slice
+}
+
+// Arg describes a positional argument accepted by a command.
+type Arg struct {
+	// The name of the argument, used to retrieve the value using request.Arg.
+	Name string
+	// A short description of the argument.
+	Usage string
+	// The type of the argument value, which defaults to ArgString.
+	Type ArgType
+	// Optional arguments can be omitted, and must follow any required arguments.
+	Optional bool
+	// Variadic arguments accept all of the remaining arguments, and must be the last argument.
+	// The value of a variadic argument is a slice of the argument type, such as []string.
+	Variadic bool
+	// Validators are evaluated against the value of the argument,
+	// or against each value of a variadic argument.
+	Validators []flags.Validator
+}
+
+// usage returns the argument as it would be displayed in the usage line, such as <name> or [name...].
+func (arg Arg) usage() string {
+	name := arg.Name
+	if arg.Variadic {
+		name += "..."
+	}
+	if arg.Optional {
+		return fmt.Sprintf("[%s]", name)
+	}
+	return fmt.Sprintf("<%s>", name)
+}
+
+// parse converts the value to the argument type and evaluates the argument validators.
+//
+// parse will return an ArgsValidationFailed error if the value fails validation.
+func (arg Arg) parse(value string) (interface{}, error) {
+	typed, err := arg.Type.parse(value)
+	if err != nil {
+		return nil, errors.ArgsValidationFailed(fmt.Sprintf("invalid value %q for argument %s", value, arg.usage()))
+	}
+	for _, validator := range arg.Validators {
+		if err := validator(typed); err != nil {
+			return nil, errors.ArgsValidationFailed(fmt.Sprintf("argument %s %s", arg.usage(), err.Error()))
+		}
+	}
+	return typed, nil
+}
+
+// ArgsValidator is used to validate the number of positional arguments supplied to a command.
+type ArgsValidator func(args []string) error
+
+// NoArgs returns an ArgsValidator that will ensure no arguments are supplied.
+func NoArgs() ArgsValidator {
+	return ExactArgs(0)
+}
+
+// ExactArgs returns an ArgsValidator that will ensure exactly n arguments are supplied.
+func ExactArgs(n int) ArgsValidator {
+	return func(args []string) error {
+		if len(args) != n {
+			return fmt.Errorf("accepts %d arg(s), received %d", n, len(args))
+		}
+		return nil
+	}
+}
+
+// MinArgs returns an ArgsValidator that will ensure at least n arguments are supplied.
+func MinArgs(n int) ArgsValidator {
+	return func(args []string) error {
+		if len(args) < n {
+			return fmt.Errorf("requires at least %d arg(s), received %d", n, len(args))
+		}
+		return nil
+	}
+}
+
+// MaxArgs returns an ArgsValidator that will ensure at most n arguments are supplied.
+func MaxArgs(n int) ArgsValidator {
+	return func(args []string) error {
+		if len(args) > n {
+			return fmt.Errorf("accepts at most %d arg(s), received %d", n, len(args))
+		}
+		return nil
+	}
+}
+
+// RangeArgs returns an ArgsValidator that will ensure between min and max arguments, inclusive, are supplied.
+func RangeArgs(min, max int) ArgsValidator {
+	return func(args []string) error {
+		if len(args) < min || len(args) > max {
+			return fmt.Errorf("accepts between %d and %d arg(s), received %d", min, max, len(args))
+		}
+		return nil
+	}
+}
+
+// argsUsage returns the arguments as they would be displayed in the usage line.
+func argsUsage(args []Arg) string {
+	usages := make([]string, 0, len(args))
+	for _, arg := range args {
+		usages = append(usages, arg.usage())
+	}
+	return strings.Join(usages, " ")
+}
+
+// checkArgs will panic if the arguments are not declared in a valid order.
+func checkArgs(args []Arg) {
+	optional := false
+	for i, arg := range args {
+		if arg.Variadic && i != len(args)-1 {
+			panic(fmt.Sprintf("variadic argument %q must be the last argument", arg.Name))
+		}
+		if optional && !arg.Optional {
+			panic(fmt.Sprintf("required argument %q cannot follow an optional argument", arg.Name))
+		}
+		optional = arg.Optional
+	}
+}
+
+// parseArgs validates the supplied arguments using the argument declarations and validators,
+// and returns the argument values keyed by name.
+//
+// parseArgs will return an ArgsValidationFailed error if any of the arguments fail validation.
+func parseArgs(declared []Arg, validators []ArgsValidator, args []string) (map[string]interface{}, error) {
+	for _, validator := range validators {
+		if err := validator(args); err != nil {
+			return nil, errors.ArgsValidationFailed(err.Error())
+		}
+	}
+
+	checkArgs(declared)
+	values := make(map[string]interface{})
+	remaining := args
+	for _, arg := range declared {
+		if len(remaining) == 0 {
+			if !arg.Optional {
+				return nil, errors.ArgsValidationFailed(fmt.Sprintf("missing argument %s", arg.usage()))
+			}
+			break
+		}
+
+		count := 1
+		if arg.Variadic {
+			count = len(remaining)
+		}
+		parsed := make([]interface{}, 0, count)
+		for _, value := range remaining[:count] {
+			typed, err := arg.parse(value)
+			if err != nil {
+				return nil, err
+			}
+			parsed = append(parsed, typed)
+		}
+		remaining = remaining[count:]
+
+		if arg.Variadic {
+			values[arg.Name] = arg.Type.slice(parsed)
+		} else {
+			values[arg.Name] = parsed[0]
+		}
+	}
+
+	if len(declared) > 0 && len(remaining) > 0 {
+		return nil, errors.ArgsValidationFailed(fmt.Sprintf("accepts at most %d arg(s), received %d", len(declared), len(args)))
+	}
+	return values, nil
+}
+
+// promptArgs prompts for the value of any required argument that has not been supplied, if the
+// input is a terminal, and returns the arguments including the values that were entered.
+//
+// If no value is entered the argument is left missing, so it will fail validation.
+func promptArgs(writer shell.ResponseWriter, input io.Reader, declared []Arg, args []string) ([]string, error) {
+	prompt := shell.NewPrompt(writer, input)
+	for i, arg := range declared {
+		if arg.Optional || i < len(args) {
+			continue
+		}
+		if !prompt.Interactive() {
+			break
+		}
+		for {
+			value, err := prompt.Text(arg.Name, "")
+			if err != nil {
+				return nil, err
+			}
+			if value == "" {
+				return args, nil
+			}
+			if _, err := arg.parse(value); err != nil {
+				fmt.Fprintln(writer.ErrorWriter(), err.Error())
+				continue
+			}
+			args = append(args, value)
+			break
+		}
+	}
+	return args, nil
+}
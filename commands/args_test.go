@@ -0,0 +1,168 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/evilmonkeyinc/golang-cli/flags"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_argsUsage(t *testing.T) {
+	assert.Equal(t, "", argsUsage(nil))
+	assert.Equal(t, "<name> [age] [tags...]", argsUsage([]Arg{
+		{Name: "name"},
+		{Name: "age", Optional: true},
+		{Name: "tags", Optional: true, Variadic: true},
+	}))
+	assert.Equal(t, "<files...>", argsUsage([]Arg{
+		{Name: "files", Variadic: true},
+	}))
+}
+
+func Test_ArgsValidators(t *testing.T) {
+
+	tests := []struct {
+		name      string
+		validator ArgsValidator
+		input     []string
+		expected  string
+	}{
+		{name: "no args", validator: NoArgs(), input: []string{}},
+		{name: "no args failed", validator: NoArgs(), input: []string{"a"}, expected: "accepts 0 arg(s), received 1"},
+		{name: "exact", validator: ExactArgs(2), input: []string{"a", "b"}},
+		{name: "exact failed", validator: ExactArgs(2), input: []string{"a"}, expected: "accepts 2 arg(s), received 1"},
+		{name: "min", validator: MinArgs(1), input: []string{"a", "b"}},
+		{name: "min failed", validator: MinArgs(1), input: []string{}, expected: "requires at least 1 arg(s), received 0"},
+		{name: "max", validator: MaxArgs(1), input: []string{"a"}},
+		{name: "max failed", validator: MaxArgs(1), input: []string{"a", "b"}, expected: "accepts at most 1 arg(s), received 2"},
+		{name: "range", validator: RangeArgs(1, 2), input: []string{"a", "b"}},
+		{name: "range failed", validator: RangeArgs(1, 2), input: []string{"a", "b", "c"}, expected: "accepts between 1 and 2 arg(s), received 3"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual := test.validator(test.input)
+			if test.expected == "" {
+				assert.Nil(t, actual)
+			} else {
+				assert.EqualError(t, actual, test.expected)
+			}
+		})
+	}
+}
+
+func Test_parseArgs(t *testing.T) {
+
+	tests := []struct {
+		name       string
+		declared   []Arg
+		validators []ArgsValidator
+		input      []string
+		expected   map[string]interface{}
+		err        string
+	}{
+		{
+			name:     "no declarations",
+			input:    []string{"a", "b"},
+			expected: map[string]interface{}{},
+		},
+		{
+			name:       "count validator",
+			validators: []ArgsValidator{ExactArgs(1)},
+			input:      []string{"a", "b"},
+			err:        "args validation failed accepts 1 arg(s), received 2",
+		},
+		{
+			name: "types",
+			declared: []Arg{
+				{Name: "string"},
+				{Name: "int", Type: ArgInt},
+				{Name: "uint", Type: ArgUint},
+				{Name: "float", Type: ArgFloat},
+				{Name: "bool", Type: ArgBool},
+				{Name: "duration", Type: ArgDuration},
+			},
+			input: []string{"a", "-1", "2", "1.5", "true", "1m"},
+			expected: map[string]interface{}{
+				"string":   "a",
+				"int":      int64(-1),
+				"uint":     uint64(2),
+				"float":    1.5,
+				"bool":     true,
+				"duration": time.Minute,
+			},
+		},
+		{
+			name:     "optional omitted",
+			declared: []Arg{{Name: "name"}, {Name: "age", Optional: true}},
+			input:    []string{"bob"},
+			expected: map[string]interface{}{"name": "bob"},
+		},
+		{
+			name:     "variadic",
+			declared: []Arg{{Name: "ports", Type: ArgUint, Variadic: true}},
+			input:    []string{"80", "443"},
+			expected: map[string]interface{}{"ports": []uint64{80, 443}},
+		},
+		{
+			name:     "missing",
+			declared: []Arg{{Name: "name"}},
+			input:    []string{},
+			err:      "args validation failed missing argument <name>",
+		},
+		{
+			name:     "too many",
+			declared: []Arg{{Name: "name"}},
+			input:    []string{"bob", "alice"},
+			err:      "args validation failed accepts at most 1 arg(s), received 2",
+		},
+		{
+			name:     "invalid type",
+			declared: []Arg{{Name: "age", Type: ArgInt}},
+			input:    []string{"old"},
+			err:      "args validation failed invalid value \"old\" for argument <age>",
+		},
+		{
+			name:     "validator",
+			declared: []Arg{{Name: "ports", Type: ArgInt, Variadic: true, Validators: []flags.Validator{flags.Min(1), flags.Max(65535)}}},
+			input:    []string{"80", "70000"},
+			err:      "args validation failed argument <ports...> must be at most 65535",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual, err := parseArgs(test.declared, test.validators, test.input)
+			if test.err != "" {
+				assert.EqualError(t, err, test.err)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, test.expected, actual)
+			}
+		})
+	}
+}
+
+func Test_checkArgs(t *testing.T) {
+	assert.PanicsWithValue(t, "variadic argument \"tags\" must be the last argument", func() {
+		checkArgs([]Arg{{Name: "tags", Variadic: true}, {Name: "name"}})
+	})
+	assert.PanicsWithValue(t, "required argument \"name\" cannot follow an optional argument", func() {
+		checkArgs([]Arg{{Name: "age", Optional: true}, {Name: "name"}})
+	})
+}
+
+func Test_promptArgs(t *testing.T) {
+	declared := []Arg{{Name: "name"}, {Name: "age", Optional: true}}
+
+	// the input is not a terminal, so the missing arguments are not prompted for
+	actual, err := promptArgs(nil, strings.NewReader("alice\n"), declared, []string{})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{}, actual)
+
+	actual, err = promptArgs(nil, strings.NewReader(""), declared, []string{"alice"})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"alice"}, actual)
+}
@@ -1,6 +1,9 @@
 package commands
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/evilmonkeyinc/golang-cli/errors"
 	"github.com/evilmonkeyinc/golang-cli/flags"
 	"github.com/evilmonkeyinc/golang-cli/shell"
@@ -15,9 +18,22 @@ type Command struct {
 	// A long description of the command.
 	Description string
 	// An example of the command used to execute the command.
+	//
+	// Any declared positional arguments are appended to the usage,
+	// so the usage should only include the command and any flags.
 	Usage string
+	// Hidden commands are excluded from command listings but can still be executed.
+	Hidden bool
+	// Deprecated commands will output this message whenever they are executed,
+	// which should point the user towards the replacement command.
+	Deprecated string
 	// An optional function to include flag definition to the command.
 	Flags flags.FlagHandlerFunction
+	// The positional arguments accepted by the command, which are validated
+	// before the Function is executed and can be retrieved using request.Arg.
+	Args []Arg
+	// Validators for the number of positional arguments supplied to the command.
+	ArgsValidators []ArgsValidator
 	// The shell handler function to be executed for the command.
 	Function shell.HandlerFunction
 }
@@ -39,7 +55,15 @@ func (command *Command) GetDescription() string {
 
 // GetUsage returns an example of the command used to execute the command.
 func (command *Command) GetUsage() string {
-	return command.Usage
+	if len(command.Args) == 0 {
+		return command.Usage
+	}
+	return strings.TrimSpace(fmt.Sprintf("%s %s", command.Usage, argsUsage(command.Args)))
+}
+
+// GetArgs returns the positional arguments accepted by the command.
+func (command *Command) GetArgs() []Arg {
+	return command.Args
 }
 
 // Define allows the function to define command-line
@@ -55,5 +79,31 @@ func (command *Command) Execute(writer shell.ResponseWriter, request *shell.Requ
 		return errors.CommandNotFound(command.Name)
 	}
 
+	if len(command.Args) > 0 || len(command.ArgsValidators) > 0 {
+		args, err := promptArgs(writer, request.Input, command.Args, request.Args)
+		if err != nil {
+			return err
+		}
+		if len(args) != len(request.Args) {
+			request = request.UpdateRequest("", args, nil, nil)
+		}
+
+		values, err := parseArgs(command.Args, command.ArgsValidators, args)
+		if err != nil {
+			return err
+		}
+		request = request.WithArgs(values)
+	}
+
 	return command.Function(writer, request)
 }
+
+// IsHidden returns true if the command should be excluded from command listings.
+func (command *Command) IsHidden() bool {
+	return command.Hidden
+}
+
+// GetDeprecated returns the deprecation message, or an empty string if the command is not deprecated.
+func (command *Command) GetDeprecated() string {
+	return command.Deprecated
+}
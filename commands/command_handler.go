@@ -19,3 +19,10 @@ type CommandHandler interface {
 	// GetUsage returns an example of the command used to execute the command.
 	GetUsage() string
 }
+
+// ArgsHandler describes a command handler that declares
+// positional arguments that can be described by the HelpCommand
+type ArgsHandler interface {
+	// GetArgs returns the positional arguments accepted by the command.
+	GetArgs() []Arg
+}
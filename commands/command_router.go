@@ -33,6 +33,11 @@ type CommandRouter struct {
 	Description string
 	// An example of the command used to execute the command.
 	Usage string
+	// Hidden commands are excluded from command listings but can still be executed.
+	Hidden bool
+	// Deprecated commands will output this message whenever they are executed,
+	// which should point the user towards the replacement command.
+	Deprecated string
 }
 
 // GetName returns the name of the command handler.
@@ -54,3 +59,13 @@ func (router *CommandRouter) GetDescription() string {
 func (router *CommandRouter) GetUsage() string {
 	return router.Usage
 }
+
+// IsHidden returns true if the command should be excluded from command listings.
+func (router *CommandRouter) IsHidden() bool {
+	return router.Hidden
+}
+
+// GetDeprecated returns the deprecation message, or an empty string if the command is not deprecated.
+func (router *CommandRouter) GetDeprecated() string {
+	return router.Deprecated
+}
@@ -13,6 +13,12 @@ var _ CommandHandler = &CommandRouter{}
 // Validate the CommandRouter struct matches the Router interface
 var _ shell.Router = &CommandRouter{}
 
+// Validate the CommandRouter struct matches the HiddenHandler interface
+var _ shell.HiddenHandler = &CommandRouter{}
+
+// Validate the CommandRouter struct matches the DeprecatedHandler interface
+var _ shell.DeprecatedHandler = &CommandRouter{}
+
 func Test_CommandRouter(t *testing.T) {
 
 	t.Run("Getters", func(t *testing.T) {
@@ -21,11 +27,15 @@ func Test_CommandRouter(t *testing.T) {
 			Summary:     "The command summary",
 			Description: "The command description",
 			Usage:       "name <arg1>",
+			Hidden:      true,
+			Deprecated:  "use other instead",
 		}
 		assert.Equal(t, "Name", command.GetName())
 		assert.Equal(t, "The command summary", command.GetSummary())
 		assert.Equal(t, "The command description", command.GetDescription())
 		assert.Equal(t, "name <arg1>", command.GetUsage())
+		assert.True(t, command.IsHidden())
+		assert.Equal(t, "use other instead", command.GetDeprecated())
 	})
 
 }
@@ -13,6 +13,12 @@ import (
 // Validate the Command struct matches the CommandHandler interface
 var _ CommandHandler = &Command{}
 
+// Validate the Command struct matches the HiddenHandler interface
+var _ shell.HiddenHandler = &Command{}
+
+// Validate the Command struct matches the DeprecatedHandler interface
+var _ shell.DeprecatedHandler = &Command{}
+
 func Test_Command(t *testing.T) {
 
 	t.Run("Getters", func(t *testing.T) {
@@ -21,11 +27,39 @@ func Test_Command(t *testing.T) {
 			Summary:     "The command summary",
 			Description: "The command description",
 			Usage:       "name <arg1>",
+			Hidden:      true,
+			Deprecated:  "use other instead",
 		}
 		assert.Equal(t, "Name", command.GetName())
 		assert.Equal(t, "The command summary", command.GetSummary())
 		assert.Equal(t, "The command description", command.GetDescription())
 		assert.Equal(t, "name <arg1>", command.GetUsage())
+		assert.True(t, command.IsHidden())
+		assert.Equal(t, "use other instead", command.GetDeprecated())
+	})
+
+	t.Run("Args", func(t *testing.T) {
+		command := &Command{
+			Name:  "add",
+			Usage: "add",
+			Args: []Arg{
+				{Name: "email"},
+				{Name: "age", Type: ArgInt, Validators: []flags.Validator{flags.Min(18)}},
+				{Name: "tags", Optional: true, Variadic: true},
+			},
+			Function: func(rw shell.ResponseWriter, r *shell.Request) error {
+				return fmt.Errorf("email=%v age=%v tags=%v", r.Arg("email"), r.Arg("age"), r.Arg("tags"))
+			},
+		}
+		assert.Equal(t, "add <email> <age> [tags...]", command.GetUsage())
+		assert.Len(t, command.GetArgs(), 3)
+
+		actual := command.Execute(nil, shell.NewRequest(nil, []string{"bob@example.com", "21", "a", "b"}, &flags.DefaultFlagSet{}, nil))
+		assert.EqualError(t, actual, "email=bob@example.com age=21 tags=[a b]")
+
+		actual = command.Execute(nil, shell.NewRequest(nil, []string{"bob@example.com"}, &flags.DefaultFlagSet{}, nil))
+		assert.True(t, errors.IsArgsValidationFailed(actual))
+		assert.EqualError(t, actual, "args validation failed missing argument <age>")
 	})
 
 	tests := []struct {
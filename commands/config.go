@@ -0,0 +1,107 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/evilmonkeyinc/golang-cli/flags"
+	"github.com/evilmonkeyinc/golang-cli/shell"
+)
+
+// NewConfigCommand returns a config CommandRouter with a view sub command
+// that outputs the resolved configuration values.
+func NewConfigCommand() *CommandRouter {
+	return NewCommandRouter("config", "Manage the configuration", "Manage the configuration file values used to set flags.", "config <command-name>", func(r shell.Router) {
+		r.Handle("view", &Command{
+			Name:        "view",
+			Summary:     "View the configuration",
+			Description: "Outputs the configuration values loaded from the configuration file.",
+			Usage:       "config view",
+			Function:    (&ConfigViewCommand{}).Execute,
+		})
+	})
+}
+
+// ConfigViewCommand outputs the configuration values that have been loaded from the configuration file.
+//
+// The values of keys used to set secret flags are redacted, including the secret flags defined
+// by any command in the routing tree.
+type ConfigViewCommand struct {
+}
+
+// Execute will execute the config view command
+func (command *ConfigViewCommand) Execute(writer shell.ResponseWriter, request *shell.Request) error {
+	source := request.FlagSet.Config()
+	if source == nil {
+		fmt.Fprintln(writer, "no configuration has been loaded")
+		return nil
+	}
+
+	secrets := secretFlags(request)
+	for _, key := range source.Keys() {
+		value, _ := source.Lookup(key)
+		for _, secret := range secrets {
+			if secret.matches(key) {
+				value = flags.RedactedValue
+				break
+			}
+		}
+		fmt.Fprintf(writer, "%s = %s\n", key, value)
+	}
+	return nil
+}
+
+// secretFlag is a secret flag and the route path of the handler that defined it.
+type secretFlag struct {
+	path []string
+	name string
+}
+
+// matches returns true if the configuration key is used to set the secret flag.
+//
+// A flag is set using its name prefixed by the path of any route it is available to,
+// such as users.add.password, so the key matches if its path is within the path the
+// flag was defined on, or the flag is inherited by the route the key path refers to.
+func (secret secretFlag) matches(key string) bool {
+	parts := strings.Split(key, ".")
+	if parts[len(parts)-1] != secret.name {
+		return false
+	}
+	keyPath := parts[:len(parts)-1]
+	for i := 0; i < len(keyPath) && i < len(secret.path); i++ {
+		if keyPath[i] != secret.path[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// secretFlags returns the secret flags defined by the request flagset and by every
+// handler in the routing tree, which are defined on a scratch flagset.
+func secretFlags(request *shell.Request) []secretFlag {
+	secrets := []secretFlag{}
+	collect := func(path []string, flagSet flags.FlagSet) {
+		for _, descriptor := range flagSet.Describe() {
+			if descriptor.Secret {
+				secrets = append(secrets, secretFlag{path: path, name: descriptor.Name})
+			}
+		}
+	}
+	define := func(path []string, handler interface{}) {
+		if flagHandler, ok := handler.(flags.FlagHandler); ok {
+			flagSet := flags.NewDefaultFlagSet()
+			flagHandler.Define(flagSet)
+			collect(path, flagSet)
+		}
+	}
+
+	collect(request.Path, request.FlagSet)
+	if root := request.Root(); root != nil {
+		define([]string{}, root)
+		shell.Walk(root, func(path []string, handler shell.Handler, middlewares []shell.Middleware) error {
+			define(path, handler)
+			return nil
+		})
+	}
+	return secrets
+}
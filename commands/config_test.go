@@ -0,0 +1,96 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/evilmonkeyinc/golang-cli/flags"
+	"github.com/evilmonkeyinc/golang-cli/shell"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NewConfigCommand(t *testing.T) {
+	command := NewConfigCommand()
+	assert.Equal(t, "config", command.GetName())
+	assert.Contains(t, command.Routes(), "view")
+}
+
+func Test_ConfigViewCommand(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	assert.Nil(t, os.WriteFile(path, []byte("verbose: true\ntoken: s3cret\nusers:\n  add:\n    role: admin\n    token: other\n"), 0600))
+
+	tests := []struct {
+		name     string
+		options  []shell.Option
+		expected string
+	}{
+		{
+			name:     "no config",
+			expected: "no configuration has been loaded\n",
+		},
+		{
+			name:     "config",
+			options:  []shell.Option{shell.OptionConfigFile(path)},
+			expected: "token = ********\nusers.add.role = admin\nusers.add.token = ********\nverbose = true\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			output := &bytes.Buffer{}
+			newShell := &shell.Shell{}
+			newShell.Options(append(test.options, shell.OptionOutputWriter(output))...)
+			newShell.Flags(flags.FlagHandlerFunction(func(fd flags.FlagDefiner) {
+				fd.Secret("token", "")
+			}))
+			newShell.Handle("config", NewConfigCommand())
+
+			os.Args = []string{"cli", "config", "view"}
+			err := newShell.Execute(context.Background())
+			assert.Nil(t, err)
+			assert.Equal(t, test.expected, output.String())
+		})
+	}
+}
+
+func Test_ConfigViewCommand_routeSecrets(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	assert.Nil(t, os.WriteFile(path, []byte("users:\n  add:\n    password: hunter2\n    role: admin\n  password: other\n"), 0600))
+
+	output := &bytes.Buffer{}
+	newShell := &shell.Shell{}
+	newShell.Options(shell.OptionConfigFile(path), shell.OptionOutputWriter(output))
+	newShell.Route("users", func(r shell.Router) {
+		r.Handle("add", &Command{
+			Name: "add",
+			Flags: func(fd flags.FlagDefiner) {
+				fd.Secret("password", "")
+				fd.String("role", "", "")
+			},
+			Function: func(writer shell.ResponseWriter, request *shell.Request) error {
+				return nil
+			},
+		})
+	})
+	newShell.Handle("config", NewConfigCommand())
+
+	os.Args = []string{"cli", "config", "view"}
+	assert.Nil(t, newShell.Execute(context.Background()))
+	assert.Equal(t, "users.add.password = ********\nusers.add.role = admin\nusers.password = ********\n", output.String())
+}
+
+func Test_secretFlag_matches(t *testing.T) {
+	secret := secretFlag{path: []string{"users", "add"}, name: "password"}
+	assert.True(t, secret.matches("password"))
+	assert.True(t, secret.matches("users.password"))
+	assert.True(t, secret.matches("users.add.password"))
+	assert.True(t, secret.matches("users.add.admin.password"))
+	assert.False(t, secret.matches("users.add.role"))
+	assert.False(t, secret.matches("groups.password"))
+	assert.False(t, secret.matches("users.remove.password"))
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"sort"
 
+	"github.com/evilmonkeyinc/golang-cli/flags"
 	"github.com/evilmonkeyinc/golang-cli/shell"
 )
 
@@ -15,15 +16,28 @@ type HelpCommand struct {
 	// If the intention is to only use the help flags, then this could
 	// be left empty to omit the usage example.
 	Usage string
+	// FlagUsage is an optional function used to render the flag usage
+	// from the flag descriptors, otherwise the FlagSet DefaultUsage is used.
+	FlagUsage func(descriptors []flags.FlagDescriptor) string
 }
 
-func (command *HelpCommand) printCommandList(writer shell.ResponseWriter, commands map[string]CommandHandler) {
-	if len(commands) > 0 {
+func (command *HelpCommand) flagUsage(flagSet flags.FlagSet) string {
+	if command.FlagUsage != nil {
+		return command.FlagUsage(flagSet.Describe())
+	}
+	return flagSet.DefaultUsage()
+}
 
-		keys := make([]string, 0, len(commands))
-		for key := range commands {
-			keys = append(keys, key)
+func (command *HelpCommand) printCommandList(writer shell.ResponseWriter, commands map[string]CommandHandler) {
+	keys := make([]string, 0, len(commands))
+	for key, cmd := range commands {
+		if hiddenHandler, ok := cmd.(shell.HiddenHandler); ok && hiddenHandler.IsHidden() {
+			continue
 		}
+		keys = append(keys, key)
+	}
+
+	if len(keys) > 0 {
 		sort.Strings(keys)
 
 		fmt.Fprintln(writer, "\nCommands")
@@ -35,6 +49,41 @@ func (command *HelpCommand) printCommandList(writer shell.ResponseWriter, comman
 	}
 }
 
+func (command *HelpCommand) printArgList(writer shell.ResponseWriter, handler interface{}) {
+	argsHandler, ok := handler.(ArgsHandler)
+	if !ok || len(argsHandler.GetArgs()) == 0 {
+		return
+	}
+
+	fmt.Fprintln(writer, "\nArguments")
+	fmt.Fprintln(writer, "------------------")
+	for _, arg := range argsHandler.GetArgs() {
+		fmt.Fprintf(writer, "%12s:\t%s\n", arg.usage(), arg.Usage)
+	}
+}
+
+func (command *HelpCommand) printPluginList(writer shell.ResponseWriter, handler interface{}) {
+	pluginRoutes, ok := handler.(shell.PluginRoutes)
+	if !ok {
+		return
+	}
+
+	plugins := pluginRoutes.DiscoverPlugins()
+	if len(plugins) > 0 {
+		keys := make([]string, 0, len(plugins))
+		for key := range plugins {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		fmt.Fprintln(writer, "\nPlugins")
+		fmt.Fprintln(writer, "------------------")
+		for _, pluginName := range keys {
+			fmt.Fprintf(writer, "%12s:\t%s\n", pluginName, plugins[pluginName])
+		}
+	}
+}
+
 func (command *HelpCommand) printCommandHandlerDetails(writer shell.ResponseWriter, request *shell.Request, commandHandler CommandHandler, args []string) error {
 
 	commands := make(map[string]CommandHandler)
@@ -58,9 +107,11 @@ func (command *HelpCommand) printCommandHandlerDetails(writer shell.ResponseWrit
 	fmt.Fprintf(writer, "  %s\n\n", commandHandler.GetSummary())
 	fmt.Fprintf(writer, "%s\n\n", commandHandler.GetDescription())
 
+	command.printArgList(writer, commandHandler)
 	command.printCommandList(writer, commands)
+	command.printPluginList(writer, commandHandler)
 
-	if usage := request.FlagSet.DefaultUsage(); usage != "" {
+	if usage := command.flagUsage(request.FlagSet); usage != "" {
 		fmt.Fprintln(writer, "\nUsage")
 		fmt.Fprintln(writer, usage)
 	}
@@ -96,8 +147,9 @@ func (command *HelpCommand) Execute(writer shell.ResponseWriter, request *shell.
 		fmt.Fprintf(writer, "\n%s: %s\n", command.Usage, fmt.Sprintf("%s or %s <command-name>", command.Usage, command.Usage))
 	}
 	command.printCommandList(writer, commands)
+	command.printPluginList(writer, routes)
 
-	if usage := request.FlagSet.DefaultUsage(); usage != "" {
+	if usage := command.flagUsage(request.FlagSet); usage != "" {
 		fmt.Fprintln(writer, "\nUsage")
 		fmt.Fprintln(writer, usage)
 	}
@@ -5,6 +5,8 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"path/filepath"
+	"runtime"
 	"strings"
 	"testing"
 
@@ -29,6 +31,12 @@ func Test_HelpCommand(t *testing.T) {
 			usage:    "help",
 			expected: []string{"pong"},
 		},
+		{
+			name:     "hidden",
+			input:    []string{"legacy"},
+			usage:    "help",
+			expected: []string{"legacy"},
+		},
 		{
 			name:  "help",
 			input: []string{"help"},
@@ -194,6 +202,17 @@ func Test_HelpCommand(t *testing.T) {
 			newShell.HandleFunction("secret", func(rw shell.ResponseWriter, r *shell.Request) error {
 				panic("this command should not be called.")
 			})
+			newShell.Handle("legacy", &Command{
+				Name:       "Legacy",
+				Summary:    "Hidden legacy command",
+				Usage:      "legacy",
+				Hidden:     true,
+				Deprecated: "use ping instead",
+				Function: func(rw shell.ResponseWriter, r *shell.Request) error {
+					fmt.Fprintln(rw, "legacy")
+					return nil
+				},
+			})
 			newShell.Handle("help", &HelpCommand{Usage: test.usage})
 
 			os.Args = append([]string{"cmd"}, test.input...)
@@ -204,6 +223,83 @@ func Test_HelpCommand(t *testing.T) {
 	}
 }
 
+func Test_HelpCommand_FlagUsage(t *testing.T) {
+	testWriter := &bytes.Buffer{}
+
+	newShell := new(shell.Shell)
+	newShell.Options(shell.OptionOutputWriter(testWriter))
+	newShell.Flags(flags.FlagHandlerFunction(func(fd flags.FlagDefiner) {
+		fd.Bool("toUpper", false, "state if the response should be uppercase")
+		fd.Bool("trace", false, "trace the request")
+		fd.Hidden("trace")
+	}))
+	newShell.Handle("ping", &Command{
+		Name:  "Ping",
+		Usage: "ping",
+		Flags: func(fd flags.FlagDefiner) {
+			fd.String("suffix", "", "a suffix for the function response")
+		},
+		Function: func(rw shell.ResponseWriter, r *shell.Request) error {
+			return nil
+		},
+	})
+	newShell.Handle("help", &HelpCommand{
+		FlagUsage: func(descriptors []flags.FlagDescriptor) string {
+			lines := []string{}
+			for _, descriptor := range descriptors {
+				if !descriptor.Hidden {
+					lines = append(lines, fmt.Sprintf("%s %s %v", descriptor.Name, descriptor.Type, descriptor.Path))
+				}
+			}
+			return strings.Join(lines, "\n")
+		},
+	})
+
+	os.Args = []string{"cmd", "help", "ping"}
+	err := newShell.Execute(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, "\nPing\n  Usage: ping\n  \n\n\n\n\nUsage\nsuffix string [help]\ntoUpper bool []\n", testWriter.String())
+}
+
+func Test_HelpCommand_Args(t *testing.T) {
+	testWriter := &bytes.Buffer{}
+
+	newShell := new(shell.Shell)
+	newShell.Options(shell.OptionOutputWriter(testWriter))
+	newShell.Handle("add", &Command{
+		Name:    "Add",
+		Summary: "Add user",
+		Usage:   "add",
+		Args: []Arg{
+			{Name: "email", Usage: "the user email"},
+			{Name: "roles", Usage: "the user roles", Optional: true, Variadic: true},
+		},
+		Function: func(rw shell.ResponseWriter, r *shell.Request) error {
+			return nil
+		},
+	})
+	newShell.Handle("help", &HelpCommand{})
+
+	os.Args = []string{"cmd", "help", "add"}
+	err := newShell.Execute(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, strings.Join([]string{
+		"",
+		"Add",
+		"  Usage: add <email> [roles...]",
+		"  Add user",
+		"",
+		"",
+		"",
+		"",
+		"Arguments",
+		"------------------",
+		"     <email>:\tthe user email",
+		"  [roles...]:\tthe user roles",
+		"",
+	}, "\n"), testWriter.String())
+}
+
 func Test_HelpCommandOption(t *testing.T) {
 
 	tests := []struct {
@@ -354,3 +450,29 @@ func Test_HelpCommandOption(t *testing.T) {
 		})
 	}
 }
+
+func Test_HelpCommandPlugins(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("plugin scripts are not supported on windows")
+	}
+
+	dir := t.TempDir()
+	err := os.WriteFile(filepath.Join(dir, "mycli-hello"), []byte("#!/bin/sh\necho hello\n"), 0755)
+	assert.Nil(t, err)
+
+	testWriter := &bytes.Buffer{}
+	newShell := &shell.Shell{}
+	newShell.Options(shell.OptionOutputWriter(testWriter))
+	newShell.Plugins(shell.NewPluginHandler("mycli", dir))
+	newShell.Handle("help", &HelpCommand{})
+
+	os.Args = []string{"cmd", "help"}
+	err = newShell.Execute(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, strings.Join([]string{
+		"",
+		"Plugins",
+		"------------------",
+		"       hello:\t" + filepath.Join(dir, "mycli-hello"),
+	}, "\n")+"\n", testWriter.String())
+}
@@ -0,0 +1,172 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/evilmonkeyinc/golang-cli/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// Format is the format of a configuration file.
+type Format string
+
+const (
+	// FormatJSON is the JSON configuration file format.
+	FormatJSON Format = "json"
+	// FormatYAML is the YAML configuration file format.
+	FormatYAML Format = "yaml"
+	// FormatTOML is the TOML configuration file format.
+	FormatTOML Format = "toml"
+	// FormatINI is the INI configuration file format.
+	FormatINI Format = "ini"
+)
+
+// FormatFromPath returns the configuration format based on the file extension.
+func FormatFromPath(path string) (Format, bool) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return FormatJSON, true
+	case ".yaml", ".yml":
+		return FormatYAML, true
+	case ".toml":
+		return FormatTOML, true
+	case ".ini", ".cfg", ".conf":
+		return FormatINI, true
+	}
+	return "", false
+}
+
+// Config contains the configuration values loaded from a configuration file.
+//
+// Configuration values are nested by route path, so the value for the role
+// flag of the users add command would be found using the key users.add.role.
+type Config struct {
+	// The path of the file the configuration was loaded from, if any.
+	Path   string
+	values map[string]interface{}
+}
+
+// New returns a new Config containing the specified values.
+func New(values map[string]interface{}) *Config {
+	if values == nil {
+		values = make(map[string]interface{})
+	}
+	return &Config{
+		values: values,
+	}
+}
+
+// Load loads the configuration file, using the file extension to determine the format.
+func Load(path string) (*Config, error) {
+	format, ok := FormatFromPath(path)
+	if !ok {
+		return nil, errors.ConfigLoadFailed(fmt.Sprintf("unsupported file extension %q", filepath.Ext(path)))
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, errors.ConfigLoadFailed(err.Error())
+	}
+	defer file.Close()
+
+	config, err := Decode(file, format)
+	if err != nil {
+		return nil, err
+	}
+	config.Path = path
+	return config, nil
+}
+
+// Decode reads the configuration values from the reader using the specified format.
+func Decode(reader io.Reader, format Format) (*Config, error) {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, errors.ConfigLoadFailed(err.Error())
+	}
+
+	values := make(map[string]interface{})
+	switch format {
+	case FormatJSON:
+		err = json.Unmarshal(data, &values)
+	case FormatYAML:
+		err = yaml.Unmarshal(data, &values)
+	case FormatTOML:
+		values, err = parseTOML(string(data))
+	case FormatINI:
+		values, err = parseINI(string(data))
+	default:
+		err = fmt.Errorf("unsupported format %q", format)
+	}
+	if err != nil {
+		return nil, errors.ConfigLoadFailed(err.Error())
+	}
+	return New(values), nil
+}
+
+// Lookup returns the configuration value for the dot separated key as a string.
+//
+// Arrays are returned as a comma separated list, and the
+// return value will be false if the key is not found or is a section.
+func (config *Config) Lookup(key string) (string, bool) {
+	var current interface{} = config.values
+	for _, part := range strings.Split(key, ".") {
+		section, ok := current.(map[string]interface{})
+		if !ok {
+			return "", false
+		}
+		if current, ok = section[part]; !ok {
+			return "", false
+		}
+	}
+	return toString(current)
+}
+
+// Keys returns the dot separated keys for every configuration value, in lexicographical order.
+func (config *Config) Keys() []string {
+	keys := []string{}
+	var visit func(prefix string, section map[string]interface{})
+	visit = func(prefix string, section map[string]interface{}) {
+		for key, value := range section {
+			if child, ok := value.(map[string]interface{}); ok {
+				visit(prefix+key+".", child)
+				continue
+			}
+			keys = append(keys, prefix+key)
+		}
+	}
+	visit("", config.values)
+	sort.Strings(keys)
+	return keys
+}
+
+// toString converts a configuration value to the string that would be used to set a flag.
+func toString(value interface{}) (string, bool) {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		return "", false
+	case nil:
+		return "", true
+	case string:
+		return typed, true
+	case float64:
+		return strconv.FormatFloat(typed, 'f', -1, 64), true
+	case []interface{}:
+		parts := make([]string, 0, len(typed))
+		for _, item := range typed {
+			part, ok := toString(item)
+			if !ok {
+				return "", false
+			}
+			parts = append(parts, part)
+		}
+		return strings.Join(parts, ","), true
+	}
+	return fmt.Sprintf("%v", value), true
+}
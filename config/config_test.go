@@ -0,0 +1,148 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/evilmonkeyinc/golang-cli/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_FormatFromPath(t *testing.T) {
+
+	tests := []struct {
+		input    string
+		expected Format
+		ok       bool
+	}{
+		{input: "config.json", expected: FormatJSON, ok: true},
+		{input: "config.yaml", expected: FormatYAML, ok: true},
+		{input: "config.YML", expected: FormatYAML, ok: true},
+		{input: "config.toml", expected: FormatTOML, ok: true},
+		{input: "config.ini", expected: FormatINI, ok: true},
+		{input: "config.cfg", expected: FormatINI, ok: true},
+		{input: "config.txt", expected: "", ok: false},
+		{input: "config", expected: "", ok: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			actual, ok := FormatFromPath(test.input)
+			assert.Equal(t, test.expected, actual)
+			assert.Equal(t, test.ok, ok)
+		})
+	}
+}
+
+func Test_Decode(t *testing.T) {
+
+	tests := []struct {
+		name   string
+		format Format
+		input  string
+	}{
+		{
+			name:   "json",
+			format: FormatJSON,
+			input:  `{"verbose": true, "port": 8080, "users": {"role": "viewer", "add": {"role": "admin", "tags": ["a", "b"]}}}`,
+		},
+		{
+			name:   "yaml",
+			format: FormatYAML,
+			input:  "verbose: true\nport: 8080\nusers:\n  role: viewer\n  add:\n    role: admin\n    tags: [a, b]\n",
+		},
+		{
+			name:   "toml",
+			format: FormatTOML,
+			input:  "verbose = true\nport = 8080\n\n[users]\nrole = \"viewer\"\n\n[users.add]\nrole = 'admin'\ntags = [\"a\", \"b\"]\n",
+		},
+		{
+			name:   "ini",
+			format: FormatINI,
+			input:  "verbose = true\nport = 8080\n\n[users]\nrole = viewer\n\n[users.add]\nrole = admin\ntags = a,b\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual, err := Decode(strings.NewReader(test.input), test.format)
+			assert.Nil(t, err)
+			assert.Equal(t, []string{"port", "users.add.role", "users.add.tags", "users.role", "verbose"}, actual.Keys())
+
+			expected := map[string]string{
+				"verbose":        "true",
+				"port":           "8080",
+				"users.role":     "viewer",
+				"users.add.role": "admin",
+				"users.add.tags": "a,b",
+			}
+			for key, value := range expected {
+				lookup, ok := actual.Lookup(key)
+				assert.True(t, ok, key)
+				assert.Equal(t, value, lookup, key)
+			}
+
+			_, ok := actual.Lookup("users")
+			assert.False(t, ok)
+			_, ok = actual.Lookup("users.add.missing")
+			assert.False(t, ok)
+			_, ok = actual.Lookup("port.missing")
+			assert.False(t, ok)
+		})
+	}
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := Decode(strings.NewReader("{"), FormatJSON)
+		assert.True(t, errors.IsConfigLoadFailed(err))
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		_, err := Decode(strings.NewReader(""), Format("xml"))
+		assert.EqualError(t, err, "config load failed unsupported format \"xml\"")
+	})
+}
+
+func Test_Load(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	assert.Nil(t, os.WriteFile(path, []byte("name: test\n"), 0600))
+
+	t.Run("valid", func(t *testing.T) {
+		actual, err := Load(path)
+		assert.Nil(t, err)
+		assert.Equal(t, path, actual.Path)
+		value, _ := actual.Lookup("name")
+		assert.Equal(t, "test", value)
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		_, err := Load(filepath.Join(dir, "config.txt"))
+		assert.EqualError(t, err, "config load failed unsupported file extension \".txt\"")
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		_, err := Load(filepath.Join(dir, "missing.json"))
+		assert.True(t, errors.IsConfigLoadFailed(err))
+	})
+}
+
+func Test_New(t *testing.T) {
+	actual := New(nil)
+	assert.Equal(t, []string{}, actual.Keys())
+
+	actual = New(map[string]interface{}{
+		"empty": nil,
+		"float": 1.5,
+		"mixed": []interface{}{"a", map[string]interface{}{}},
+	})
+	value, ok := actual.Lookup("empty")
+	assert.Equal(t, "", value)
+	assert.True(t, ok)
+	value, ok = actual.Lookup("float")
+	assert.Equal(t, "1.5", value)
+	assert.True(t, ok)
+	_, ok = actual.Lookup("mixed")
+	assert.False(t, ok)
+}
@@ -0,0 +1,62 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/ini.v1"
+)
+
+// parseINI parses an INI document into nested sections.
+//
+// Section names can be nested using dots, such as [users.add], and values
+// can be separated from keys using either = or :. Lines starting with ; or #
+// are comments, as is any text following a space and a ; or #, unless the value
+// is enclosed in double quotes, and quoted values will have their quotes removed.
+func parseINI(data string) (map[string]interface{}, error) {
+	file, err := ini.LoadSources(ini.LoadOptions{
+		// comment characters must follow a space, so values such as URLs are not truncated
+		SpaceBeforeInlineComment:  true,
+		UnescapeValueDoubleQuotes: true,
+	}, []byte(data))
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]interface{})
+	for _, section := range file.Sections() {
+		current := values
+		if name := section.Name(); name != ini.DefaultSection {
+			if current, err = sectionFor(values, strings.Split(name, ".")); err != nil {
+				return nil, fmt.Errorf("section %q: %v", name, err)
+			}
+		}
+		for _, key := range section.Keys() {
+			if _, ok := current[key.Name()].(map[string]interface{}); ok {
+				return nil, fmt.Errorf("section %q: key %q is a section", section.Name(), key.Name())
+			}
+			current[key.Name()] = key.Value()
+		}
+	}
+	return values, nil
+}
+
+// sectionFor returns the nested section for the keys, creating any missing sections.
+func sectionFor(values map[string]interface{}, keys []string) (map[string]interface{}, error) {
+	current := values
+	for _, key := range keys {
+		existing, ok := current[key]
+		if !ok {
+			section := make(map[string]interface{})
+			current[key] = section
+			current = section
+			continue
+		}
+		section, ok := existing.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("key %q is not a section", key)
+		}
+		current = section
+	}
+	return current, nil
+}
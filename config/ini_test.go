@@ -0,0 +1,69 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_parseINI(t *testing.T) {
+
+	t.Run("values", func(t *testing.T) {
+		actual, err := parseINI(`; comment
+# comment
+name = test ; trailing comment
+quoted = "a ; b"
+colon: value
+url = http://localhost/#home
+
+[users.add]
+role = 'admin'
+`)
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]interface{}{
+			"name":   "test",
+			"quoted": "a ; b",
+			"colon":  "value",
+			"url":    "http://localhost/#home",
+			"users": map[string]interface{}{
+				"add": map[string]interface{}{
+					"role": "admin",
+				},
+			},
+		}, actual)
+	})
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "invalid section",
+			input:    "[section",
+			expected: "unclosed section: [section",
+		},
+		{
+			name:     "missing equals",
+			input:    "key",
+			expected: "key-value delimiter not found: key",
+		},
+		{
+			name:     "key is a section",
+			input:    "[users.add]\n[users]\nadd = value",
+			expected: "section \"users\": key \"add\" is a section",
+		},
+		{
+			name:     "section is a key",
+			input:    "users = value\n[users]",
+			expected: "section \"users\": key \"users\" is not a section",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := parseINI(test.input)
+			assert.EqualError(t, err, test.expected)
+		})
+	}
+}
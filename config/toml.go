@@ -0,0 +1,51 @@
+package config
+
+import (
+	"time"
+
+	"github.com/BurntSushi/toml"
+)
+
+// parseTOML parses a TOML document into nested sections.
+func parseTOML(data string) (map[string]interface{}, error) {
+	values := make(map[string]interface{})
+	if _, err := toml.Decode(data, &values); err != nil {
+		return nil, err
+	}
+	return normalizeTOML(values).(map[string]interface{}), nil
+}
+
+// normalizeTOML converts the decoded TOML values to the values used by the other formats,
+// so dates and times are converted to strings and arrays of tables to arrays.
+func normalizeTOML(value interface{}) interface{} {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		for key, item := range typed {
+			typed[key] = normalizeTOML(item)
+		}
+		return typed
+	case []map[string]interface{}:
+		items := make([]interface{}, len(typed))
+		for i, item := range typed {
+			items[i] = normalizeTOML(item)
+		}
+		return items
+	case []interface{}:
+		for i, item := range typed {
+			typed[i] = normalizeTOML(item)
+		}
+		return typed
+	case time.Time:
+		// local dates and times are decoded using locations with these names
+		switch typed.Location().String() {
+		case "date-local":
+			return typed.Format("2006-01-02")
+		case "time-local":
+			return typed.Format("15:04:05.999999999")
+		case "datetime-local":
+			return typed.Format("2006-01-02T15:04:05.999999999")
+		}
+		return typed.Format(time.RFC3339Nano)
+	}
+	return value
+}
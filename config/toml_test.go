@@ -0,0 +1,89 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_parseTOML(t *testing.T) {
+
+	t.Run("values", func(t *testing.T) {
+		actual, err := parseTOML(`# comment
+title = "a # title" # trailing comment
+count = 1_000
+hex = 0xff
+ratio = 0.5
+enabled = false
+"quoted key" = 'literal \n'
+"dotted.key" = "quoted"
+server.host = "localhost"
+point = { x = 1, y = 2 }
+created = 1979-05-27T07:32:00Z
+day = 1979-05-27
+time = 07:32:00
+
+[database . primary]
+ports = [
+  8000,
+  8001, # trailing comma
+]
+names = ["a,b", 'c']
+
+[[servers]]
+name = "alpha"
+`)
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]interface{}{
+			"title":      "a # title",
+			"count":      int64(1000),
+			"hex":        int64(255),
+			"ratio":      0.5,
+			"enabled":    false,
+			"quoted key": `literal \n`,
+			"dotted.key": "quoted",
+			"server": map[string]interface{}{
+				"host": "localhost",
+			},
+			"point": map[string]interface{}{
+				"x": int64(1),
+				"y": int64(2),
+			},
+			"created": "1979-05-27T07:32:00Z",
+			"day":     "1979-05-27",
+			"time":    "07:32:00",
+			"database": map[string]interface{}{
+				"primary": map[string]interface{}{
+					"ports": []interface{}{int64(8000), int64(8001)},
+					"names": []interface{}{"a,b", "c"},
+				},
+			},
+			"servers": []interface{}{
+				map[string]interface{}{"name": "alpha"},
+			},
+		}, actual)
+	})
+
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{name: "invalid table", input: "[table"},
+		{name: "missing equals", input: "\nkey"},
+		{name: "missing value", input: "key ="},
+		{name: "invalid value", input: "key = value"},
+		{name: "invalid string", input: "key = 'value"},
+		{name: "invalid array", input: "key = [1, 2"},
+		{name: "leading zero", input: "key = 012"},
+		{name: "duplicate key", input: "key = 1\nkey = 2"},
+		{name: "key is not a table", input: "key = 1\n[key]"},
+		{name: "dotted key is not a table", input: "key = 1\nkey.child = 1"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := parseTOML(test.input)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -3,28 +3,59 @@ package errors
 import (
 	"errors"
 	"fmt"
+	"sort"
+	"strings"
 )
 
 var (
-	errCommandNotFound    error = errors.New("command not found")
-	errDuplicateCommand   error = errors.New("command has already been declared")
-	errFlagsetParseFailed error = errors.New("flagset parse failed")
-	errFlagsetSetFailed   error = errors.New("flagset set failed")
-	errHelpRequested      error = errors.New("help requested")
-	errOptionIsInvalid    error = errors.New("option paramaters are undefined or invalid")
-	errOptionIsSet        error = errors.New("option has already been used or shell has already been initialized")
+	errArgsValidationFailed    error = errors.New("args validation failed")
+	errCommandNotFound         error = errors.New("command not found")
+	errConfigLoadFailed        error = errors.New("config load failed")
+	errDuplicateCommand        error = errors.New("command has already been declared")
+	errFlagsetDecodeFailed     error = errors.New("flagset decode failed")
+	errFlagsetParseFailed      error = errors.New("flagset parse failed")
+	errFlagsetSetFailed        error = errors.New("flagset set failed")
+	errFlagsetValidationFailed error = errors.New("flagset validation failed")
+	errHelpRequested           error = errors.New("help requested")
+	errOptionIsInvalid         error = errors.New("option paramaters are undefined or invalid")
+	errOptionIsSet             error = errors.New("option has already been used or shell has already been initialized")
 )
 
+// ArgsValidationFailed returns an args validation failed error
+func ArgsValidationFailed(reason string) error {
+	return fmt.Errorf("%w %s", errArgsValidationFailed, reason)
+}
+
+// IsArgsValidationFailed determines if the specified error is an args validation failed error
+func IsArgsValidationFailed(err error) bool {
+	return errors.Is(err, errArgsValidationFailed)
+}
+
 // CommandNotFound returns a command not found error
 func CommandNotFound(command string) error {
 	return fmt.Errorf("'%s' %w", command, errCommandNotFound)
 }
 
+// ConfigLoadFailed returns a config load failed error
+func ConfigLoadFailed(reason string) error {
+	return fmt.Errorf("%w %s", errConfigLoadFailed, reason)
+}
+
+// IsConfigLoadFailed determines if the specified error is a config load failed error
+func IsConfigLoadFailed(err error) bool {
+	return errors.Is(err, errConfigLoadFailed)
+}
+
 // DuplicateCommand returns a duplicate command error
 func DuplicateCommand(command string) error {
 	return fmt.Errorf("'%s' %w", command, errDuplicateCommand)
 }
 
+// FlagsetDecodeFailed returns a flagset decode failed error
+func FlagsetDecodeFailed(reason string) error {
+	return fmt.Errorf("%w %s", errFlagsetDecodeFailed, reason)
+}
+
 // FlagsetParseFailed returns a flagset parse failed error
 func FlagsetParseFailed(reason string) error {
 	return fmt.Errorf("%w %s", errFlagsetParseFailed, reason)
@@ -35,6 +66,53 @@ func FlagsetSetFailed(reason string) error {
 	return fmt.Errorf("%w %s", errFlagsetSetFailed, reason)
 }
 
+// FlagsetValidationFailed returns a flagset validation failed error
+// for the supplied validation failures and display names, keyed by flag name.
+func FlagsetValidationFailed(failures map[string]error, displayNames map[string]string) error {
+	return &FlagValidationError{
+		Failures:     failures,
+		DisplayNames: displayNames,
+	}
+}
+
+// IsFlagsetValidationFailed determines if the specified error is a flagset validation failed error
+func IsFlagsetValidationFailed(err error) bool {
+	return errors.Is(err, errFlagsetValidationFailed)
+}
+
+// FlagValidationError is returned when one or more flags fail validation.
+type FlagValidationError struct {
+	// Failures contains the validation failures keyed by flag name.
+	Failures map[string]error
+	// DisplayNames contains the flag names as they are used on the command-line, such as --output,
+	// keyed by flag name. Any flag without a display name is displayed using a single dash, such as -output.
+	DisplayNames map[string]string
+}
+
+// Error returns the validation failures as a single error message.
+func (err *FlagValidationError) Error() string {
+	names := make([]string, 0, len(err.Failures))
+	for name := range err.Failures {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	messages := make([]string, 0, len(names))
+	for _, name := range names {
+		displayName, ok := err.DisplayNames[name]
+		if !ok {
+			displayName = "-" + name
+		}
+		messages = append(messages, fmt.Sprintf("%s %s", displayName, err.Failures[name].Error()))
+	}
+	return fmt.Sprintf("%s: %s", errFlagsetValidationFailed.Error(), strings.Join(messages, ", "))
+}
+
+// Unwrap returns the underlying flagset validation failed error.
+func (err *FlagValidationError) Unwrap() error {
+	return errFlagsetValidationFailed
+}
+
 // HelpRequested returns a help requested error
 func HelpRequested(reason string) error {
 	return fmt.Errorf("%w %s", errHelpRequested, reason)
@@ -8,6 +8,36 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+func Test_ArgsValidationFailed(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "standard",
+			input:    "missing argument <name>",
+			expected: "args validation failed missing argument <name>",
+		},
+		{
+			name:     "empty",
+			input:    "",
+			expected: "args validation failed ",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual := ArgsValidationFailed(test.input)
+			assert.Equal(t, test.expected, actual.Error())
+			assert.True(t, IsArgsValidationFailed(actual))
+		})
+	}
+
+	assert.False(t, IsArgsValidationFailed(fmt.Errorf("args validation failed")))
+}
+
 func Test_CommandNotFound(t *testing.T) {
 
 	tests := []struct {
@@ -41,6 +71,36 @@ func Test_CommandNotFound(t *testing.T) {
 	}
 }
 
+func Test_ConfigLoadFailed(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "standard",
+			input:    "invalid file",
+			expected: "config load failed invalid file",
+		},
+		{
+			name:     "empty",
+			input:    "",
+			expected: "config load failed ",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual := ConfigLoadFailed(test.input)
+			assert.Equal(t, test.expected, actual.Error())
+			assert.True(t, IsConfigLoadFailed(actual))
+		})
+	}
+
+	assert.False(t, IsConfigLoadFailed(fmt.Errorf("config load failed")))
+}
+
 func Test_DuplicateCommand(t *testing.T) {
 
 	tests := []struct {
@@ -74,6 +134,34 @@ func Test_DuplicateCommand(t *testing.T) {
 	}
 }
 
+func Test_FlagsetDecodeFailed(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "standard",
+			input:    "invalid target",
+			expected: "flagset decode failed invalid target",
+		},
+		{
+			name:     "empty",
+			input:    "",
+			expected: "flagset decode failed ",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual := FlagsetDecodeFailed(test.input)
+			assert.Equal(t, test.expected, actual.Error())
+			assert.True(t, errors.Is(actual, errFlagsetDecodeFailed))
+		})
+	}
+}
+
 func Test_FlagsetParseFailed(t *testing.T) {
 
 	tests := []struct {
@@ -273,3 +361,56 @@ func Test_OptionIsInvalid(t *testing.T) {
 		})
 	}
 }
+
+func Test_FlagsetValidationFailed(t *testing.T) {
+
+	tests := []struct {
+		name         string
+		input        map[string]error
+		displayNames map[string]string
+		expected     string
+	}{
+		{
+			name: "one",
+			input: map[string]error{
+				"name": fmt.Errorf("is required"),
+			},
+			expected: "flagset validation failed: -name is required",
+		},
+		{
+			name: "display names",
+			input: map[string]error{
+				"output": fmt.Errorf("is required"),
+				"v":      fmt.Errorf("must be at most 3"),
+			},
+			displayNames: map[string]string{
+				"output": "--output",
+				"v":      "-v",
+			},
+			expected: "flagset validation failed: --output is required, -v must be at most 3",
+		},
+		{
+			name: "sorted",
+			input: map[string]error{
+				"name":  fmt.Errorf("is required"),
+				"count": fmt.Errorf("must be at least 1"),
+			},
+			expected: "flagset validation failed: -count must be at least 1, -name is required",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual := FlagsetValidationFailed(test.input, test.displayNames)
+			assert.Equal(t, test.expected, actual.Error())
+			assert.True(t, IsFlagsetValidationFailed(actual))
+			assert.True(t, IsFlagsetValidationFailed(fmt.Errorf("wrapped %w", actual)))
+
+			var validationError *FlagValidationError
+			assert.True(t, errors.As(actual, &validationError))
+			assert.Equal(t, test.input, validationError.Failures)
+		})
+	}
+
+	assert.False(t, IsFlagsetValidationFailed(fmt.Errorf("other")))
+}
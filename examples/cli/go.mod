@@ -3,3 +3,13 @@ module examples/cli
 go 1.17
 
 require github.com/evilmonkeyinc/golang-cli v0.9.1
+
+require (
+	github.com/BurntSushi/toml v1.3.2 // indirect
+	golang.org/x/sys v0.10.0 // indirect
+	golang.org/x/term v0.10.0 // indirect
+	gopkg.in/ini.v1 v1.67.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+replace github.com/evilmonkeyinc/golang-cli => ../..
@@ -26,7 +26,8 @@ func main() {
 		Function: func(rw shell.ResponseWriter, r *shell.Request) error {
 			message := "pong"
 
-			if suffix, ok := r.FlagValues().GetString("suffix"); ok {
+			if r.FlagValues().Changed("suffix") {
+				suffix, _ := r.FlagValues().GetString("suffix")
 				message = fmt.Sprintf("%s%s", message, suffix)
 			}
 
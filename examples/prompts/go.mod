@@ -2,12 +2,14 @@ module examples/prompts
 
 go 1.17
 
-require (
-	github.com/evilmonkeyinc/golang-cli v0.6.0
-	github.com/manifoldco/promptui v0.9.0
-)
+require github.com/evilmonkeyinc/golang-cli v0.6.0
 
 require (
-	github.com/chzyer/readline v0.0.0-20180603132655-2972be24d48e // indirect
-	golang.org/x/sys v0.0.0-20181122145206-62eef0e2fa9b // indirect
+	github.com/BurntSushi/toml v1.3.2 // indirect
+	golang.org/x/sys v0.10.0 // indirect
+	golang.org/x/term v0.10.0 // indirect
+	gopkg.in/ini.v1 v1.67.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
+
+replace github.com/evilmonkeyinc/golang-cli => ../..
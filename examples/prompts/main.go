@@ -1,10 +1,11 @@
 /**
-Example of using github.com/manifoldco/promptui for stylized user prompts.
+Example of prompting for user input.
 
-promptui will function in command-line and shell mode without any modification.
+Prompts will function in command-line and shell mode without any modification, as they
+write to the response writer and read from the request input.
 
-shell response writer conforms to io.WriteCloser interface so it can be used for prompt
-if the output or error writers have been updated using the shell options if required.
+Required flags and positional arguments that are missing will also be prompted for
+automatically when the input is a terminal, such as the deploy command's environment.
 **/
 package main
 
@@ -15,8 +16,9 @@ import (
 	"os/signal"
 	"syscall"
 
+	"github.com/evilmonkeyinc/golang-cli/commands"
+	"github.com/evilmonkeyinc/golang-cli/flags"
 	"github.com/evilmonkeyinc/golang-cli/shell"
-	"github.com/manifoldco/promptui"
 )
 
 func main() {
@@ -25,34 +27,46 @@ func main() {
 
 	newShell := new(shell.Shell)
 	newShell.HandleFunction("login", func(rw shell.ResponseWriter, r *shell.Request) error {
+		prompt := shell.NewPrompt(rw, r.Input)
 
-		usernamePrompt := promptui.Prompt{
-			Label:   "Username",
-			Default: "username",
-			Stdout:  rw,
-		}
-
-		username, err := usernamePrompt.Run()
+		username, err := prompt.Text("Username", "username")
 		if err != nil {
 			return err
 		}
 
-		passwordPrompt := promptui.Prompt{
-			Label:  "Password",
-			Mask:   '*',
-			Stdout: rw,
+		password, err := prompt.Password("Password")
+		if err != nil {
+			return err
 		}
 
-		password, err := passwordPrompt.Run()
+		remember, err := prompt.Confirm("Remember me", false)
 		if err != nil {
 			return err
 		}
 
-		fmt.Println(fmt.Sprintf("%s:%s", username, password))
+		fmt.Fprintf(rw, "%s:%s remember=%t\n", username, password, remember)
 
 		return nil
 	})
 
+	newShell.Handle("deploy", &commands.Command{
+		Name:    "deploy",
+		Summary: "deploy a service",
+		Usage:   "deploy",
+		Flags: func(fd flags.FlagDefiner) {
+			fd.Enum("environment", "", []string{"staging", "production"}, "the target environment")
+			fd.Required("environment")
+		},
+		Args: []commands.Arg{
+			{Name: "service", Usage: "the service to deploy"},
+		},
+		Function: func(rw shell.ResponseWriter, r *shell.Request) error {
+			environment, _ := r.FlagValues().GetString("environment")
+			fmt.Fprintf(rw, "deploying %s to %s\n", r.Arg("service"), environment)
+			return nil
+		},
+	})
+
 	if len(os.Args) > 1 {
 		newShell.Execute(ctx)
 		return
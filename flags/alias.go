@@ -0,0 +1,44 @@
+package flags
+
+import "fmt"
+
+// aliasNames returns the aliases of a flag to be included in the flag usage,
+// using flagName to format each of the alias names.
+func aliasNames(aliases []string, flagName func(name string) string) string {
+	names := ""
+	for _, alias := range aliases {
+		names += ", " + flagName(alias)
+	}
+	return names
+}
+
+// deprecationWarning returns the warning for a flag used by the specified name, and false if
+// neither the name used nor the flag itself is deprecated.
+func deprecationWarning(meta *flagMeta, flagName, name, displayName string) (string, bool) {
+	message, ok := meta.deprecated[name]
+	if !ok {
+		message, ok = meta.deprecated[flagName]
+	}
+	if !ok {
+		return "", false
+	}
+	return fmt.Sprintf("Flag %q is deprecated, %s", displayName, message), true
+}
+
+// addWarning adds the warning to the list of warnings, unless it has already been added.
+func addWarning(warnings []string, warning string) []string {
+	for _, existing := range warnings {
+		if existing == warning {
+			return warnings
+		}
+	}
+	return append(warnings, warning)
+}
+
+// posixFlagName formats a flag name as it would be used with a PosixFlagSet.
+func posixFlagName(name string) string {
+	if len(name) == 1 {
+		return "-" + name
+	}
+	return "--" + name
+}
@@ -0,0 +1,35 @@
+package flags
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_aliasNames(t *testing.T) {
+	assert.Equal(t, "", aliasNames(nil, posixFlagName))
+	assert.Equal(t, ", -o, --outfile", aliasNames([]string{"o", "outfile"}, posixFlagName))
+}
+
+func Test_deprecationWarning(t *testing.T) {
+	meta := &flagMeta{deprecated: map[string]string{"outfile": "use --output instead"}}
+
+	warning, deprecated := deprecationWarning(meta, "output", "outfile", "--outfile")
+	assert.True(t, deprecated)
+	assert.Equal(t, "Flag \"--outfile\" is deprecated, use --output instead", warning)
+
+	_, deprecated = deprecationWarning(meta, "output", "output", "--output")
+	assert.False(t, deprecated)
+
+	meta.deprecate("output", "use --format instead")
+	warning, deprecated = deprecationWarning(meta, "output", "o", "-o")
+	assert.True(t, deprecated)
+	assert.Equal(t, "Flag \"-o\" is deprecated, use --format instead", warning)
+}
+
+func Test_addWarning(t *testing.T) {
+	warnings := addWarning(nil, "a")
+	warnings = addWarning(warnings, "b")
+	warnings = addWarning(warnings, "a")
+	assert.Equal(t, []string{"a", "b"}, warnings)
+}
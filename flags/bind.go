@@ -0,0 +1,339 @@
+package flags
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/evilmonkeyinc/golang-cli/errors"
+)
+
+var (
+	durationType = reflect.TypeOf(time.Duration(0))
+	valueType    = reflect.TypeOf((*Value)(nil)).Elem()
+)
+
+// Bind defines flags using the tagged fields of the struct pointed to by v.
+//
+// The following field tags are supported:
+//
+//	flag:"name,n"    the flag name and optional shorthand, fields without a flag tag are ignored
+//	                 the shorthand is only defined when binding to a PosixFlagSet
+//	usage:"text"     the flag usage string
+//	default:"value"  the default value, otherwise the current field value is used
+//	env:"A,B"        the environment variables bound to the flag
+//	required:"true"  marks the flag as required
+//	local:"true"     marks the flag as local, so it is not inherited by sub commands
+//	hidden:"true"    marks the flag as hidden, so it is omitted from the flag usage
+//	deprecated:"msg" marks the flag as deprecated, with the message included in the warning
+//	file:"true"      allows the flag value to be read from a file using @path
+//	secret:"true"    the value of a string flag is redacted, which is defined using Secret
+//	choices:"a,b"    the values accepted by a string flag, which is defined using Enum
+//
+// Supported field types are bool, string, the int, uint, and float types, time.Duration,
+// slices of those types, and any type implementing Value. Nested struct fields with a flag
+// tag will prefix the names of their flags, such as db-host, and embedded structs are flattened.
+//
+// Bind will panic if v is not a pointer to a struct, if a field type is not supported,
+// or if a default value is invalid.
+func Bind(definer FlagDefiner, v interface{}) {
+	target, err := structValue(v)
+	if err != nil {
+		panic(err.Error())
+	}
+
+	visitFields(target, "", func(field reflect.Value, tag reflect.StructTag, name string) error {
+		bindField(definer, field, tag, name)
+		return nil
+	})
+}
+
+// Decode sets the tagged fields of the struct pointed to by v using the flag values,
+// using the same field tags as Bind. Fields for flags that have not been defined are ignored.
+//
+// Fields that implement Value are also ignored, as Bind uses the field itself to store the
+// flag value, so setting it again would duplicate the values of a field that appends to its value.
+func Decode(values FlagValues, v interface{}) error {
+	target, err := structValue(v)
+	if err != nil {
+		return errors.FlagsetDecodeFailed(err.Error())
+	}
+
+	return visitFields(target, "", func(field reflect.Value, tag reflect.StructTag, name string) error {
+		if isValue(field) {
+			return nil
+		}
+		raw := values.Get(lookupName(name))
+		if raw == nil {
+			return nil
+		}
+		if err := assign(field, raw); err != nil {
+			return errors.FlagsetDecodeFailed(fmt.Sprintf("flag %s: %v", lookupName(name), err))
+		}
+		return nil
+	})
+}
+
+// structValue returns the struct that v points to.
+func structValue(v interface{}) (reflect.Value, error) {
+	value := reflect.ValueOf(v)
+	if value.Kind() != reflect.Ptr || value.IsNil() || value.Elem().Kind() != reflect.Struct {
+		return reflect.Value{}, fmt.Errorf("expected a pointer to a struct, got %T", v)
+	}
+	return value.Elem(), nil
+}
+
+// isValue returns true if the field implements the Value interface.
+func isValue(field reflect.Value) bool {
+	return field.CanAddr() && field.Addr().Type().Implements(valueType)
+}
+
+// visitFields calls fn for each of the tagged fields of the struct, including those in nested structs.
+func visitFields(target reflect.Value, prefix string, fn func(field reflect.Value, tag reflect.StructTag, name string) error) error {
+	targetType := target.Type()
+	for i := 0; i < targetType.NumField(); i++ {
+		structField := targetType.Field(i)
+		field := target.Field(i)
+		name, hasName := structField.Tag.Lookup("flag")
+		if name == "-" {
+			continue
+		}
+
+		if structField.PkgPath != "" {
+			// unexported fields cannot be set, but the exported
+			// fields of an embedded struct can be
+			if structField.Anonymous && field.Kind() == reflect.Struct {
+				if err := visitFields(field, prefix, fn); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		if field.Kind() == reflect.Struct && !isValue(field) {
+			if hasName {
+				if err := visitFields(field, prefix+name+"-", fn); err != nil {
+					return err
+				}
+			} else if structField.Anonymous {
+				if err := visitFields(field, prefix, fn); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		if !hasName {
+			continue
+		}
+		if err := fn(field, structField.Tag, prefix+name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// lookupName returns the first of the comma separated flag names, which is used to retrieve the flag.
+func lookupName(name string) string {
+	return strings.TrimSpace(strings.Split(name, ",")[0])
+}
+
+// bindField defines the flag for a single struct field.
+func bindField(definer FlagDefiner, field reflect.Value, tag reflect.StructTag, name string) {
+	usage := tag.Get("usage")
+	defaultValue, hasDefault := tag.Lookup("default")
+	if _, ok := definer.(*PosixFlagSet); !ok {
+		// only the PosixFlagSet supports a shorthand after the flag name
+		name = lookupName(name)
+	}
+
+	if isValue(field) {
+		value := field.Addr().Interface().(Value)
+		if hasDefault {
+			if err := value.Set(defaultValue); err != nil {
+				panic(fmt.Sprintf("flag %s: invalid default value %q: %v", name, defaultValue, err))
+			}
+		}
+		definer.Var(value, name, usage)
+	} else {
+		if hasDefault {
+			if err := setField(field, defaultValue); err != nil {
+				panic(fmt.Sprintf("flag %s: invalid default value %q: %v", name, defaultValue, err))
+			}
+		}
+		if choices := tag.Get("choices"); choices != "" && field.Kind() == reflect.String {
+			definer.Enum(name, field.String(), strings.Split(choices, ","), usage)
+		} else if secret, _ := strconv.ParseBool(tag.Get("secret")); secret && field.Kind() == reflect.String {
+			definer.Secret(name, usage)
+		} else {
+			defineField(definer, field, name, usage)
+		}
+	}
+
+	if env := tag.Get("env"); env != "" {
+		definer.Env(lookupName(name), strings.Split(env, ",")...)
+	}
+	if required, _ := strconv.ParseBool(tag.Get("required")); required {
+		definer.Required(lookupName(name))
+	}
+	if local, _ := strconv.ParseBool(tag.Get("local")); local {
+		definer.Local(lookupName(name))
+	}
+	if hidden, _ := strconv.ParseBool(tag.Get("hidden")); hidden {
+		definer.Hidden(lookupName(name))
+	}
+	if message := tag.Get("deprecated"); message != "" {
+		definer.Deprecated(lookupName(name), message)
+	}
+	if file, _ := strconv.ParseBool(tag.Get("file")); file {
+		definer.FileValue(lookupName(name))
+	}
+}
+
+// defineField defines the flag using the type of the struct field, with the current field value as the default.
+func defineField(definer FlagDefiner, field reflect.Value, name, usage string) {
+	if field.Type() == durationType {
+		definer.Duration(name, time.Duration(field.Int()), usage)
+		return
+	}
+
+	switch field.Kind() {
+	case reflect.Bool:
+		definer.Bool(name, field.Bool(), usage)
+		return
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		definer.Int(name, field.Int(), usage)
+		return
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		definer.Uint(name, field.Uint(), usage)
+		return
+	case reflect.Float32, reflect.Float64:
+		definer.Float(name, field.Float(), usage)
+		return
+	case reflect.String:
+		definer.String(name, field.String(), usage)
+		return
+	case reflect.Slice:
+		if value := sliceFlagValue(field); value != nil {
+			definer.Var(value, name, usage)
+			return
+		}
+	}
+	panic(fmt.Sprintf("flag %s: unsupported field type %s", name, field.Type()))
+}
+
+// sliceFlagValue returns the Value used for a slice field, using the current field value as the default.
+func sliceFlagValue(field reflect.Value) Value {
+	switch field.Type().Elem().Kind() {
+	case reflect.String:
+		value := &StringArrayFlag{}
+		for i := 0; i < field.Len(); i++ {
+			*value = append(*value, field.Index(i).String())
+		}
+		return value
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		defaultValue := make([]int64, field.Len())
+		for i := range defaultValue {
+			defaultValue[i] = field.Index(i).Int()
+		}
+		return newIntSliceValue(defaultValue)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		defaultValue := make([]uint64, field.Len())
+		for i := range defaultValue {
+			defaultValue[i] = field.Index(i).Uint()
+		}
+		return newUintSliceValue(defaultValue)
+	case reflect.Float32, reflect.Float64:
+		defaultValue := make([]float64, field.Len())
+		for i := range defaultValue {
+			defaultValue[i] = field.Index(i).Float()
+		}
+		return newFloatSliceValue(defaultValue)
+	}
+	return nil
+}
+
+// setField sets the struct field from the string, parsing it in the same way as the flag value.
+func setField(field reflect.Value, s string) error {
+	var value Value
+	if field.Type() == durationType {
+		value = newDurationValue(0)
+	} else {
+		switch field.Kind() {
+		case reflect.Bool:
+			value = newBoolValue(false)
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			value = newIntValue(0)
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			value = newUintValue(0)
+		case reflect.Float32, reflect.Float64:
+			value = newFloatValue(0)
+		case reflect.String:
+			value = newStringValue("")
+		case reflect.Slice:
+			value = sliceFlagValue(reflect.MakeSlice(field.Type(), 0, 0))
+		}
+	}
+	if value == nil {
+		return fmt.Errorf("unsupported field type %s", field.Type())
+	}
+	if err := value.Set(s); err != nil {
+		return err
+	}
+	return assign(field, value.Get())
+}
+
+// assign sets the struct field to the flag value, converting it to the field type if required.
+func assign(field reflect.Value, raw interface{}) error {
+	value := reflect.ValueOf(raw)
+	if value.Type().AssignableTo(field.Type()) {
+		field.Set(value)
+		return nil
+	}
+
+	switch field.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if value.Kind() == reflect.Int64 {
+			if field.OverflowInt(value.Int()) {
+				return fmt.Errorf("value %v overflows %s", raw, field.Type())
+			}
+			field.SetInt(value.Int())
+			return nil
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if value.Kind() == reflect.Uint64 {
+			if field.OverflowUint(value.Uint()) {
+				return fmt.Errorf("value %v overflows %s", raw, field.Type())
+			}
+			field.SetUint(value.Uint())
+			return nil
+		}
+	case reflect.Float32, reflect.Float64:
+		if value.Kind() == reflect.Float64 {
+			if field.OverflowFloat(value.Float()) {
+				return fmt.Errorf("value %v overflows %s", raw, field.Type())
+			}
+			field.SetFloat(value.Float())
+			return nil
+		}
+	case reflect.Slice:
+		if value.Kind() == reflect.Slice {
+			slice := reflect.MakeSlice(field.Type(), value.Len(), value.Len())
+			for i := 0; i < value.Len(); i++ {
+				if err := assign(slice.Index(i), value.Index(i).Interface()); err != nil {
+					return err
+				}
+			}
+			field.Set(slice)
+			return nil
+		}
+	}
+
+	if value.Kind() == field.Kind() && value.Type().ConvertibleTo(field.Type()) {
+		field.Set(value.Convert(field.Type()))
+		return nil
+	}
+	return fmt.Errorf("cannot assign %s to %s", value.Type(), field.Type())
+}
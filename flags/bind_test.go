@@ -0,0 +1,336 @@
+package flags
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testLevel is a custom Value used for testing.
+type testLevel int
+
+func (level *testLevel) Set(s string) error {
+	switch strings.ToLower(s) {
+	case "low":
+		*level = 1
+	case "high":
+		*level = 2
+	default:
+		return fmt.Errorf("unknown level")
+	}
+	return nil
+}
+
+func (level *testLevel) Get() interface{} { return *level }
+
+func (level *testLevel) String() string { return fmt.Sprintf("%d", int(*level)) }
+
+type testDatabase struct {
+	Host string `flag:"host" default:"localhost" usage:"the database host"`
+	Port uint16 `flag:"port" default:"5432"`
+}
+
+type testCommon struct {
+	Verbose bool `flag:"verbose,v" usage:"verbose output"`
+}
+
+type testOptions struct {
+	testCommon
+	Name     string        `flag:"name" usage:"the user name" env:"TEST_BIND_NAME" required:"true"`
+	Count    int           `flag:"count" default:"3"`
+	Ratio    float32       `flag:"ratio"`
+	Timeout  time.Duration `flag:"timeout" default:"5s"`
+	Tags     []string      `flag:"tags"`
+	Ports    []int         `flag:"ports" default:"80,443"`
+	Level    testLevel     `flag:"level" default:"low"`
+	Database testDatabase  `flag:"db"`
+	Ignored  string
+	Skipped  string `flag:"-"`
+	internal string
+}
+
+func Test_Bind(t *testing.T) {
+	t.Setenv("TEST_BIND_NAME", "env-name")
+
+	options := &testOptions{
+		Ratio: 0.5,
+	}
+	flagSet := NewPosixFlagSet()
+	Bind(flagSet, options)
+
+	names := []string{}
+	flagSet.VisitAll(func(name string, value Value) {
+		names = append(names, name)
+	})
+	assert.Equal(t, []string{"count", "db-host", "db-port", "level", "name", "ports", "ratio", "tags", "timeout", "verbose"}, names)
+
+	_, err := flagSet.Parse([]string{"-v", "--count", "5", "--tags", "a,b", "--ports", "8080", "--level", "high", "--db-host", "db"})
+	assert.Nil(t, err)
+	assert.Nil(t, flagSet.Validate())
+	assert.Equal(t, SourceEnvironment, flagSet.Source("name"))
+	assert.Equal(t, testLevel(2), options.Level)
+
+	// the Level field implements Value, so it is only set by the flag it is bound to
+	decoded := &testOptions{}
+	assert.Nil(t, Decode(flagSet, decoded))
+	assert.Equal(t, &testOptions{
+		testCommon: testCommon{Verbose: true},
+		Name:       "env-name",
+		Count:      5,
+		Ratio:      0.5,
+		Timeout:    5 * time.Second,
+		Tags:       []string{"a", "b"},
+		Ports:      []int{8080},
+		Database: testDatabase{
+			Host: "db",
+			Port: 5432,
+		},
+	}, decoded)
+
+	t.Run("shorthand", func(t *testing.T) {
+		options := &testCommon{}
+		flagSet := NewDefaultFlagSet()
+		Bind(flagSet, options)
+
+		names := []string{}
+		flagSet.VisitAll(func(name string, value Value) {
+			names = append(names, name)
+		})
+		assert.Equal(t, []string{"verbose"}, names)
+
+		_, err := flagSet.Parse([]string{"-verbose"})
+		assert.Nil(t, err)
+		assert.Nil(t, Decode(flagSet, options))
+		assert.True(t, options.Verbose)
+	})
+
+	t.Run("required", func(t *testing.T) {
+		flagSet := NewDefaultFlagSet()
+		Bind(flagSet, &struct {
+			Name string `flag:"name" required:"yes"`
+			ID   string `flag:"id" required:"true"`
+		}{})
+		_, err := flagSet.Parse([]string{})
+		assert.Nil(t, err)
+		assert.EqualError(t, flagSet.Validate(), "flagset validation failed: -id is required")
+	})
+
+	t.Run("local", func(t *testing.T) {
+		flagSet := NewDefaultFlagSet()
+		Bind(flagSet, &struct {
+			Name string `flag:"name"`
+			ID   string `flag:"id" local:"true"`
+		}{})
+		names := []string{}
+		flagSet.SubFlagSet("sub").VisitAll(func(name string, value Value) {
+			names = append(names, name)
+		})
+		assert.Equal(t, []string{"name"}, names)
+	})
+
+	t.Run("hidden and deprecated", func(t *testing.T) {
+		flagSet := NewDefaultFlagSet()
+		Bind(flagSet, &struct {
+			Name  string `flag:"name"`
+			Trace bool   `flag:"trace" hidden:"true"`
+			User  string `flag:"user" deprecated:"use -name instead"`
+		}{})
+		assert.Equal(t, "  -name string\n    \t\n  -user string\n    \t\n", flagSet.DefaultUsage())
+		_, err := flagSet.Parse([]string{"-trace", "-user", "bob"})
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"Flag \"-user\" is deprecated, use -name instead"}, flagSet.Warnings())
+	})
+
+	t.Run("secret", func(t *testing.T) {
+		options := &struct {
+			Password string `flag:"password" secret:"true"`
+		}{}
+		flagSet := NewDefaultFlagSet()
+		Bind(flagSet, options)
+		_, err := flagSet.Parse([]string{"-password", "s3cret"})
+		assert.Nil(t, err)
+		assert.True(t, flagSet.Describe()[0].Secret)
+		assert.Nil(t, Decode(flagSet, options))
+		assert.Equal(t, "s3cret", options.Password)
+	})
+
+	t.Run("choices", func(t *testing.T) {
+		options := &struct {
+			Output string `flag:"output" default:"json" choices:"json,yaml"`
+		}{}
+		flagSet := NewDefaultFlagSet()
+		Bind(flagSet, options)
+		_, err := flagSet.Parse([]string{"-output", "xml"})
+		assert.EqualError(t, err, "flagset parse failed invalid value \"xml\" for flag -output: must be one of: json, yaml")
+		_, err = flagSet.Parse([]string{"-output", "yaml"})
+		assert.Nil(t, err)
+		assert.Nil(t, Decode(flagSet, options))
+		assert.Equal(t, "yaml", options.Output)
+	})
+
+	t.Run("value", func(t *testing.T) {
+		options := &struct {
+			Labels StringArrayFlag `flag:"labels"`
+			Level  testLevel       `flag:"level"`
+		}{}
+		flagSet := NewDefaultFlagSet()
+		Bind(flagSet, options)
+		_, err := flagSet.Parse([]string{"-labels", "a,b", "-labels", "c", "-level", "high"})
+		assert.Nil(t, err)
+		assert.Nil(t, Decode(flagSet, options))
+		assert.Equal(t, StringArrayFlag{"a", "b", "c"}, options.Labels)
+		assert.Equal(t, testLevel(2), options.Level)
+	})
+
+	t.Run("usage", func(t *testing.T) {
+		flagSet := NewDefaultFlagSet()
+		Bind(flagSet, &testDatabase{})
+		assert.Equal(t, "  -host string\n"+
+			"    \tthe database host (default \"localhost\")\n"+
+			"  -port uint\n"+
+			"    \t (default 5432)\n", flagSet.DefaultUsage())
+	})
+}
+
+func Test_Bind_Panics(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		input    interface{}
+		expected string
+	}{
+		{
+			name:     "not a pointer",
+			input:    testOptions{},
+			expected: "expected a pointer to a struct, got flags.testOptions",
+		},
+		{
+			name:     "not a struct",
+			input:    new(string),
+			expected: "expected a pointer to a struct, got *string",
+		},
+		{
+			name: "unsupported type",
+			input: &struct {
+				Values map[string]string `flag:"values"`
+			}{},
+			expected: "flag values: unsupported field type map[string]string",
+		},
+		{
+			name: "unsupported slice",
+			input: &struct {
+				Values []bool `flag:"values"`
+			}{},
+			expected: "flag values: unsupported field type []bool",
+		},
+		{
+			name: "invalid default",
+			input: &struct {
+				Count int8 `flag:"count" default:"abc"`
+			}{},
+			expected: "flag count: invalid default value \"abc\": parse error",
+		},
+		{
+			name: "default overflow",
+			input: &struct {
+				Count int8 `flag:"count" default:"300"`
+			}{},
+			expected: "flag count: invalid default value \"300\": value 300 overflows int8",
+		},
+		{
+			name: "invalid value default",
+			input: &struct {
+				Level testLevel `flag:"level" default:"medium"`
+			}{},
+			expected: "flag level: invalid default value \"medium\": unknown level",
+		},
+		{
+			name: "unsupported default",
+			input: &struct {
+				Values map[string]string `flag:"values" default:"a"`
+			}{},
+			expected: "flag values: invalid default value \"a\": unsupported field type map[string]string",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			testPanic(t, func() {
+				Bind(NewDefaultFlagSet(), test.input)
+			}, test.expected)
+		})
+	}
+}
+
+func Test_Decode(t *testing.T) {
+
+	t.Run("invalid target", func(t *testing.T) {
+		err := Decode(NewDefaultFlagSet(), "invalid")
+		assert.EqualError(t, err, "flagset decode failed expected a pointer to a struct, got string")
+	})
+
+	t.Run("undefined flags", func(t *testing.T) {
+		options := &testDatabase{Host: "unchanged"}
+		assert.Nil(t, Decode(NewDefaultFlagSet(), options))
+		assert.Equal(t, "unchanged", options.Host)
+	})
+
+	t.Run("overflow", func(t *testing.T) {
+		flagSet := NewDefaultFlagSet()
+		flagSet.Int("count", 300, "")
+		flagSet.Uint("size", 300, "")
+		flagSet.Float("ratio", 1e300, "")
+
+		err := Decode(flagSet, &struct {
+			Count int8 `flag:"count"`
+		}{})
+		assert.EqualError(t, err, "flagset decode failed flag count: value 300 overflows int8")
+
+		err = Decode(flagSet, &struct {
+			Size uint8 `flag:"size"`
+		}{})
+		assert.EqualError(t, err, "flagset decode failed flag size: value 300 overflows uint8")
+
+		err = Decode(flagSet, &struct {
+			Ratio float32 `flag:"ratio"`
+		}{})
+		assert.EqualError(t, err, "flagset decode failed flag ratio: value 1e+300 overflows float32")
+	})
+
+	t.Run("conversions", func(t *testing.T) {
+		type name string
+		flagSet := NewDefaultFlagSet()
+		flagSet.String("name", "bob", "")
+		flagSet.Var(newUintSliceValue([]uint64{1, 2}), "ids", "")
+		flagSet.Var(newFloatSliceValue([]float64{0.5}), "ratios", "")
+
+		options := &struct {
+			Name   name      `flag:"name"`
+			IDs    []uint8   `flag:"ids"`
+			Ratios []float32 `flag:"ratios"`
+		}{}
+		assert.Nil(t, Decode(flagSet, options))
+		assert.Equal(t, name("bob"), options.Name)
+		assert.Equal(t, []uint8{1, 2}, options.IDs)
+		assert.Equal(t, []float32{0.5}, options.Ratios)
+	})
+
+	t.Run("invalid type", func(t *testing.T) {
+		flagSet := NewDefaultFlagSet()
+		flagSet.String("count", "abc", "")
+		flagSet.Var(newIntSliceValue([]int64{300}), "values", "")
+
+		err := Decode(flagSet, &struct {
+			Count int `flag:"count"`
+		}{})
+		assert.EqualError(t, err, "flagset decode failed flag count: cannot assign string to int")
+
+		err = Decode(flagSet, &struct {
+			Values []int8 `flag:"values"`
+		}{})
+		assert.EqualError(t, err, "flagset decode failed flag values: value 300 overflows int8")
+	})
+}
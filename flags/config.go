@@ -0,0 +1,52 @@
+package flags
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/evilmonkeyinc/golang-cli/errors"
+)
+
+// ConfigSource provides flag values from a configuration source, such as a configuration file.
+type ConfigSource interface {
+	// Lookup returns the configuration value for the dot separated key, such as users.add.role.
+	Lookup(key string) (string, bool)
+	// Keys returns the dot separated keys for every configuration value, in lexicographical order.
+	Keys() []string
+}
+
+// applyConfig sets the flag value from the most specific configuration key, starting with the
+// full route path and working back to the root, unless the flag value has already been set from
+// the command-line or environment variables.
+func applyConfig(meta *flagMeta, source ConfigSource, path []string, value flag.Value, flagName, displayName string) error {
+	if source == nil || meta.source > SourceConfig {
+		return nil
+	}
+	for i := len(path); i >= 0; i-- {
+		key := strings.Join(append(append([]string{}, path[:i]...), flagName), ".")
+		configValue, ok := source.Lookup(key)
+		if !ok {
+			continue
+		}
+		if meta.configKey == key {
+			// the value has already been set from this key by a parent flagset
+			return nil
+		}
+		if err := value.Set(configValue); err != nil {
+			return errors.FlagsetParseFailed(fmt.Sprintf("invalid value %q for flag %s from config %s: %v", configValue, displayName, key, err))
+		}
+		meta.source = SourceConfig
+		meta.configKey = key
+		return nil
+	}
+	return nil
+}
+
+// subPath returns the route path for a sub flagset.
+func subPath(path []string, name string) []string {
+	if name == "" {
+		return path
+	}
+	return append(append([]string{}, path...), name)
+}
@@ -0,0 +1,125 @@
+package flags
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testConfig is a ConfigSource used for testing.
+type testConfig map[string]string
+
+func (config testConfig) Lookup(key string) (string, bool) {
+	value, ok := config[key]
+	return value, ok
+}
+
+func (config testConfig) Keys() []string {
+	keys := make([]string, 0, len(config))
+	for key := range config {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func Test_applyConfig(t *testing.T) {
+	source := testConfig{
+		"role":           "viewer",
+		"users.add.role": "admin",
+		"count":          "abc",
+	}
+
+	tests := []struct {
+		name        string
+		meta        *flagMeta
+		source      ConfigSource
+		path        []string
+		flagName    string
+		expected    string
+		expectedKey string
+		err         string
+	}{
+		{
+			name:     "no source",
+			meta:     &flagMeta{},
+			flagName: "role",
+			expected: "default",
+		},
+		{
+			name:        "root key",
+			meta:        &flagMeta{},
+			source:      source,
+			path:        []string{"users"},
+			flagName:    "role",
+			expected:    "viewer",
+			expectedKey: "role",
+		},
+		{
+			name:        "nested key",
+			meta:        &flagMeta{},
+			source:      source,
+			path:        []string{"users", "add"},
+			flagName:    "role",
+			expected:    "admin",
+			expectedKey: "users.add.role",
+		},
+		{
+			name:        "replaces parent key",
+			meta:        &flagMeta{source: SourceConfig, configKey: "role"},
+			source:      source,
+			path:        []string{"users", "add"},
+			flagName:    "role",
+			expected:    "admin",
+			expectedKey: "users.add.role",
+		},
+		{
+			name:        "same key",
+			meta:        &flagMeta{source: SourceConfig, configKey: "role"},
+			source:      source,
+			path:        []string{"users"},
+			flagName:    "role",
+			expected:    "default",
+			expectedKey: "role",
+		},
+		{
+			name:     "environment",
+			meta:     &flagMeta{source: SourceEnvironment},
+			source:   source,
+			flagName: "role",
+			expected: "default",
+		},
+		{
+			name:     "missing",
+			meta:     &flagMeta{},
+			source:   source,
+			flagName: "name",
+			expected: "default",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			value := newStringValue("default")
+			err := applyConfig(test.meta, test.source, test.path, value, test.flagName, "-"+test.flagName)
+			assert.Nil(t, err)
+			assert.Equal(t, test.expected, value.String())
+			assert.Equal(t, test.expectedKey, test.meta.configKey)
+		})
+	}
+
+	t.Run("invalid", func(t *testing.T) {
+		meta := &flagMeta{}
+		err := applyConfig(meta, source, nil, newIntValue(0), "count", "-count")
+		assert.EqualError(t, err, "flagset parse failed invalid value \"abc\" for flag -count from config count: parse error")
+		assert.Equal(t, SourceDefault, meta.source)
+	})
+}
+
+func Test_subPath(t *testing.T) {
+	path := []string{"users"}
+	assert.Equal(t, path, subPath(path, ""))
+	assert.Equal(t, []string{"users", "add"}, subPath(path, "add"))
+	assert.Equal(t, []string{"users"}, path)
+}
@@ -0,0 +1,478 @@
+package flags
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/evilmonkeyinc/golang-cli/errors"
+)
+
+// definedFlag represents the state of a flag defined in a flagset.
+type definedFlag struct {
+	name      string
+	shorthand string
+	usage     string
+	value     Value
+	defValue  string
+	// displayName is the flag name as it would be used on the command-line, such as -name or --name
+	displayName string
+}
+
+// flagCore contains the defined flags, their metadata and value sources, and the validation
+// rules shared by the DefaultFlagSet and PosixFlagSet, which only differ in how the flags are
+// defined, parsed, and displayed in the flag usage.
+type flagCore struct {
+	// defined contains the defined flags keyed by flag name
+	defined map[string]*definedFlag
+	// names contains the flag name keyed by each of its aliases and shorthand
+	names        map[string]string
+	metadata     map[string]*flagMeta
+	groups       []*flagGroup
+	interspersed bool
+	parsed       bool
+	envPrefix    string
+	config       ConfigSource
+	path         []string
+	scopes       []*localScope
+	snapshot     *snapshot
+	warnings     []string
+	files        fileSettings
+}
+
+func (core *flagCore) setup() {
+	if core.defined == nil {
+		core.defined = make(map[string]*definedFlag)
+	}
+	if core.names == nil {
+		core.names = make(map[string]string)
+	}
+	if core.metadata == nil {
+		core.metadata = make(map[string]*flagMeta)
+	}
+}
+
+// sub returns the core for a sub flagset, which shares any flags that are not local.
+func (core *flagCore) sub(name string) flagCore {
+	core.setup()
+
+	scope, groups := newLocalScope(core.metadata, core.groups, core.Get)
+	sub := flagCore{
+		interspersed: core.interspersed,
+		groups:       groups,
+		envPrefix:    core.envPrefix,
+		config:       core.config,
+		path:         subPath(core.path, name),
+		scopes:       subScopes(core.scopes, scope),
+		snapshot:     core.snapshot,
+		files:        core.files,
+	}
+	sub.setup()
+
+	// metadata is shared with the sub flagset so that any
+	// flags set by the parent are known to the child
+	for name, meta := range core.metadata {
+		if !meta.local {
+			sub.metadata[name] = meta
+		}
+	}
+	for name, f := range core.defined {
+		if _, ok := sub.metadata[name]; ok {
+			sub.defined[name] = f
+		}
+	}
+	for alias, name := range core.names {
+		if _, ok := sub.metadata[name]; ok {
+			sub.names[alias] = name
+		}
+	}
+	return sub
+}
+
+// resolve returns the name of the flag for the specified flag name, alias, or shorthand.
+func (core *flagCore) resolve(name string) string {
+	if flagName, ok := core.names[name]; ok {
+		return flagName
+	}
+	return name
+}
+
+// lookup returns the flag with the specified name, alias, or shorthand.
+func (core *flagCore) lookup(name string) *definedFlag {
+	core.setup()
+	return core.defined[core.resolve(name)]
+}
+
+// meta returns the metadata for the flag with the specified name, alias, or shorthand, creating it if required.
+func (core *flagCore) meta(name string) *flagMeta {
+	core.setup()
+	name = core.resolve(name)
+	meta, ok := core.metadata[name]
+	if !ok {
+		meta = &flagMeta{}
+		core.metadata[name] = meta
+	}
+	return meta
+}
+
+// register records the definition of the flag, and of its shorthand if it has one.
+func (core *flagCore) register(f *definedFlag) {
+	core.setup()
+	core.defined[f.name] = f
+	if f.shorthand != "" && f.shorthand != f.name {
+		core.names[f.shorthand] = f.name
+	}
+	meta := core.meta(f.name)
+	meta.path = core.path
+	meta.displayName = f.displayName
+	core.snapshot.record(f, meta)
+}
+
+// alias records the alias as an alternate name for the named flag.
+func (core *flagCore) alias(name, alias string) {
+	name = core.resolve(name)
+	core.names[alias] = name
+	meta := core.meta(name)
+	meta.aliases = append(meta.aliases, alias)
+}
+
+// sortedFlags returns the defined flags sorted by name.
+func (core *flagCore) sortedFlags() []*definedFlag {
+	core.setup()
+	sorted := make([]*definedFlag, 0, len(core.defined))
+	for _, f := range core.defined {
+		sorted = append(sorted, f)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].name < sorted[j].name
+	})
+	return sorted
+}
+
+// setByName marks the flag as set on the command-line, adding a warning if the name used was deprecated.
+func (core *flagCore) setByName(f *definedFlag, name, displayName string) {
+	meta := core.meta(f.name)
+	meta.source = SourceCommandLine
+	if warning, deprecated := deprecationWarning(meta, f.name, name, displayName); deprecated {
+		core.warnings = addWarning(core.warnings, warning)
+	}
+}
+
+// fileValue returns the value for the flag, which will be read from a file
+// or the input if file values have been enabled for the flag.
+func (core *flagCore) fileValue(f *definedFlag, displayName, value string) (string, error) {
+	if isBoolFlag(f.value) {
+		return value, nil
+	}
+	value, err := core.files.resolve(core.meta(f.name), displayName, value)
+	if err != nil {
+		return value, errors.FlagsetParseFailed(err.Error())
+	}
+	return value, nil
+}
+
+// applySources sets the value of any flag that was not set on the command-line
+// from its environment variables or the configuration source.
+func (core *flagCore) applySources() error {
+	for _, f := range core.sortedFlags() {
+		meta := core.meta(f.name)
+		if err := applyEnv(meta, envVars(meta, core.envPrefix, f.name), f.value, f.displayName); err != nil {
+			return err
+		}
+		if err := applyConfig(meta, core.config, core.path, f.value, f.name, f.displayName); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addGroup adds the flag group, using the flag name for any aliases or shorthand names.
+func (core *flagCore) addGroup(kind groupKind, names []string) {
+	group := newFlagGroup(kind, names)
+	for i, name := range group.names {
+		core.meta(name)
+		group.names[i] = core.resolve(name)
+	}
+	core.groups = append(core.groups, group)
+}
+
+// Parsed returns true if Parse has been called.
+func (core *flagCore) Parsed() bool {
+	return core.parsed
+}
+
+// SetInterspersed sets whether Parse will allow flags to be interspersed with non-flag arguments.
+func (core *flagCore) SetInterspersed(interspersed bool) {
+	core.interspersed = interspersed
+}
+
+// Interspersed returns true if Parse will allow flags to be interspersed with non-flag arguments.
+func (core *flagCore) Interspersed() bool {
+	return core.interspersed
+}
+
+// Set sets the value of the named flag.
+func (core *flagCore) Set(name, value string) error {
+	f := core.lookup(name)
+	if f == nil {
+		return errors.FlagsetSetFailed(fmt.Sprintf("no such flag -%s", name))
+	}
+	if err := f.value.Set(value); err != nil {
+		return errors.FlagsetSetFailed(err.Error())
+	}
+	core.meta(f.name).source = SourceCommandLine
+	return nil
+}
+
+// Env binds the named flag to one or more environment variables, which will be used
+// to set the flag value if it has not been set on the command-line.
+func (core *flagCore) Env(name string, envVars ...string) {
+	meta := core.meta(name)
+	meta.envVars = append(meta.envVars, envVars...)
+}
+
+// Local marks the named flag as local to the flagset it is defined on, so it will not be inherited
+// by sub routers and sub commands. Flags that are not marked as local are persistent and inherited.
+func (core *flagCore) Local(name string) {
+	core.meta(name).local = true
+}
+
+// Hidden marks the named flag as hidden, so it can still be used but is omitted from the flag usage.
+func (core *flagCore) Hidden(name string) {
+	core.meta(name).hidden = true
+}
+
+// Deprecated marks the named flag as deprecated, which will produce a warning containing
+// the message, such as "use -output instead", whenever the flag is used.
+func (core *flagCore) Deprecated(name string, message string) {
+	core.meta(name).deprecate(core.resolve(name), message)
+}
+
+// Warnings returns the warnings produced by the last call to Parse, such as the use of a deprecated flag.
+func (core *flagCore) Warnings() []string {
+	return core.warnings
+}
+
+// FileValue allows the named flag value to be read from a file on the command-line using @path,
+// such as -password @/run/secrets/password, or from the input using @-.
+// Any trailing newlines are removed from the value.
+func (core *flagCore) FileValue(name string) {
+	core.meta(name).fileValue = true
+}
+
+// SetFileValues sets whether every flag value can be read from a file, rather than
+// only the flags marked using FileValue.
+func (core *flagCore) SetFileValues(enabled bool) {
+	core.files.enabled = enabled
+}
+
+// SetFileLimit sets the maximum size, in bytes, of a flag value read from a file or the input,
+// which defaults to DefaultFileLimit.
+func (core *flagCore) SetFileLimit(limit int64) {
+	core.files.limit = limit
+}
+
+// SetInput sets the reader used to read flag values using @-, which defaults to os.Stdin.
+func (core *flagCore) SetInput(reader io.Reader) {
+	core.files.input = reader
+}
+
+// Negatable allows the named bool flag to be set to false using its name with a no- prefix, such as -no-color.
+func (core *flagCore) Negatable(name string) {
+	core.meta(name).negatable = true
+}
+
+// SetEnvPrefix sets the prefix used to bind every flag to an environment variable,
+// such as MYCLI_ binding the flag named user-name to MYCLI_USER_NAME.
+func (core *flagCore) SetEnvPrefix(prefix string) {
+	core.envPrefix = prefix
+}
+
+// SetConfig sets the configuration source used to set any flag that has not been set
+// on the command-line or from environment variables, and applies it to the defined flags.
+func (core *flagCore) SetConfig(source ConfigSource) error {
+	core.config = source
+	return core.applySources()
+}
+
+// Config returns the configuration source, or nil if one has not been set.
+func (core *flagCore) Config() ConfigSource {
+	return core.config
+}
+
+// Changed returns true if the named flag has been set on the command-line,
+// from environment variables, or from a configuration source, rather than using its default value.
+func (core *flagCore) Changed(name string) bool {
+	return core.Source(name) != SourceDefault
+}
+
+// Source returns the source of the named flag value.
+func (core *flagCore) Source(name string) Source {
+	if meta, ok := core.metadata[core.resolve(name)]; ok {
+		return meta.source
+	}
+	return SourceDefault
+}
+
+// Required marks the named flag as required, validation will fail if the flag has not been set.
+func (core *flagCore) Required(name string) {
+	core.meta(name).required = true
+}
+
+// Validators adds validators to the named flag, which will be evaluated during validation if the flag has been set.
+func (core *flagCore) Validators(name string, validators ...Validator) {
+	meta := core.meta(name)
+	meta.validators = append(meta.validators, validators...)
+}
+
+// ExactlyOneOf declares that exactly one of the named flags must be set.
+func (core *flagCore) ExactlyOneOf(names ...string) {
+	core.addGroup(exactlyOneOf, names)
+}
+
+// AtMostOneOf declares that no more than one of the named flags can be set.
+func (core *flagCore) AtMostOneOf(names ...string) {
+	core.addGroup(atMostOneOf, names)
+}
+
+// AllOrNone declares that if any of the named flags are set then all of them must be set.
+func (core *flagCore) AllOrNone(names ...string) {
+	core.addGroup(allOrNone, names)
+}
+
+// Snapshot saves the current state of the flags so they can be restored using Reset,
+// including the state of any flags defined by sub flagsets after the snapshot was taken.
+func (core *flagCore) Snapshot() {
+	core.snapshot = &snapshot{}
+	for _, f := range core.sortedFlags() {
+		core.snapshot.flags = append(core.snapshot.flags, newFlagState(f, core.meta(f.name)))
+	}
+}
+
+// Reset restores the flags to the state saved by Snapshot, including any flags
+// defined by sub flagsets since the snapshot was taken, the flag values are
+// restored to their default values.
+func (core *flagCore) Reset() {
+	core.snapshot.restore()
+}
+
+// Validate validates the flag values using the required flags and flag validators,
+// and will return a FlagValidationError if any of the flags fail validation.
+func (core *flagCore) Validate() error {
+	core.setup()
+	return validateFlags(core.metadata, core.groups, core.Get, core.scopes...)
+}
+
+// VisitAll visits the defined flags in lexicographical order, calling fn for each.
+func (core *flagCore) VisitAll(fn func(name string, value Value)) {
+	for _, f := range core.sortedFlags() {
+		fn(f.name, f.value)
+	}
+}
+
+// Describe returns the descriptors of the defined flags in lexicographical order,
+// including any hidden flags.
+func (core *flagCore) Describe() []FlagDescriptor {
+	descriptors := []FlagDescriptor{}
+	for _, f := range core.sortedFlags() {
+		descriptor := newFlagDescriptor(f.name, f.value, f.defValue, f.usage, core.meta(f.name), core.envPrefix)
+		if f.shorthand != f.name {
+			descriptor.Shorthand = f.shorthand
+		}
+		descriptors = append(descriptors, descriptor)
+	}
+	return descriptors
+}
+
+// Get returns the value of the named flag.
+func (core *flagCore) Get(name string) interface{} {
+	f := core.lookup(name)
+	if f == nil {
+		return nil
+	}
+	return f.value.Get()
+}
+
+// GetBool returns the value of a named flag as a bool.
+func (core *flagCore) GetBool(name string) (bool, bool) {
+	return getBool(core.Get(name))
+}
+
+// GetInt returns the value of a named flag as a int64.
+func (core *flagCore) GetInt(name string) (int64, bool) {
+	return getInt(core.Get(name))
+}
+
+// GetUint returns the value of a named flag as a uint64.
+func (core *flagCore) GetUint(name string) (uint64, bool) {
+	return getUint(core.Get(name))
+}
+
+// GetString returns the value of a named flag as a string.
+func (core *flagCore) GetString(name string) (string, bool) {
+	return getString(core.Get(name))
+}
+
+// GetStringArray returns the value of a named flag as a string array.
+func (core *flagCore) GetStringArray(name string) ([]string, bool) {
+	return getStringArray(core.Get(name))
+}
+
+// GetFloat returns the value of a named flag as a float64.
+func (core *flagCore) GetFloat(name string) (float64, bool) {
+	return getFloat(core.Get(name))
+}
+
+// GetDuration returns the value of a named flag as a time.Duration.
+func (core *flagCore) GetDuration(name string) (time.Duration, bool) {
+	return getDuration(core.Get(name))
+}
+
+// GetIntSlice returns the value of a named flag as a int64 slice.
+func (core *flagCore) GetIntSlice(name string) ([]int64, bool) {
+	return getIntSlice(core.Get(name))
+}
+
+// GetUintSlice returns the value of a named flag as a uint64 slice.
+func (core *flagCore) GetUintSlice(name string) ([]uint64, bool) {
+	return getUintSlice(core.Get(name))
+}
+
+// GetFloatSlice returns the value of a named flag as a float64 slice.
+func (core *flagCore) GetFloatSlice(name string) ([]float64, bool) {
+	return getFloatSlice(core.Get(name))
+}
+
+// GetStringMap returns the value of a named flag as a map[string]string.
+func (core *flagCore) GetStringMap(name string) (map[string]string, bool) {
+	return getStringMap(core.Get(name))
+}
+
+// GetTime returns the value of a named flag as a time.Time.
+func (core *flagCore) GetTime(name string) (time.Time, bool) {
+	return getTime(core.Get(name))
+}
+
+// GetIP returns the value of a named flag as a net.IP.
+func (core *flagCore) GetIP(name string) (net.IP, bool) {
+	return getIP(core.Get(name))
+}
+
+// GetIPNet returns the value of a named flag as a net.IPNet.
+func (core *flagCore) GetIPNet(name string) (net.IPNet, bool) {
+	return getIPNet(core.Get(name))
+}
+
+// GetURL returns the value of a named flag as a *url.URL.
+func (core *flagCore) GetURL(name string) (*url.URL, bool) {
+	return getURL(core.Get(name))
+}
+
+// GetRegexp returns the value of a named flag as a *regexp.Regexp.
+func (core *flagCore) GetRegexp(name string) (*regexp.Regexp, bool) {
+	return getRegexp(core.Get(name))
+}
@@ -0,0 +1,58 @@
+package flags
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_flagCore_register(t *testing.T) {
+	core := &flagCore{path: []string{"users"}}
+	value := newStringValue("")
+	core.register(&definedFlag{name: "output", shorthand: "o", value: value})
+	core.alias("o", "out")
+
+	assert.Equal(t, "output", core.resolve("o"))
+	assert.Equal(t, "output", core.resolve("out"))
+	assert.Equal(t, "missing", core.resolve("missing"))
+	assert.Equal(t, core.lookup("output"), core.lookup("out"))
+	assert.Nil(t, core.lookup("missing"))
+	assert.Equal(t, []string{"users"}, core.meta("o").path)
+	assert.Equal(t, []string{"out"}, core.meta("output").aliases)
+}
+
+func Test_flagCore_sub(t *testing.T) {
+	core := &flagCore{}
+	core.register(&definedFlag{name: "output", shorthand: "o", value: newStringValue("")})
+	core.register(&definedFlag{name: "force", value: newBoolValue(false)})
+	core.alias("force", "f")
+	core.Local("force")
+	core.Required("output")
+
+	sub := core.sub("users")
+	assert.Equal(t, []string{"users"}, sub.path)
+	assert.Equal(t, core.lookup("output"), sub.lookup("o"))
+	assert.Nil(t, sub.lookup("force"))
+	assert.Nil(t, sub.lookup("f"))
+	assert.Equal(t, core.meta("output"), sub.meta("output"))
+	assert.NotNil(t, sub.scopes)
+}
+
+func Test_flagCore_addGroup(t *testing.T) {
+	core := &flagCore{}
+	core.register(&definedFlag{name: "output", shorthand: "o", value: newStringValue("")})
+	core.AtMostOneOf("o", "format")
+	assert.Equal(t, []string{"output", "format"}, core.groups[0].names)
+}
+
+func Test_flagCore_Set(t *testing.T) {
+	core := &flagCore{}
+	core.register(&definedFlag{name: "count", shorthand: "c", value: newIntValue(0)})
+
+	assert.Nil(t, core.Set("c", "2"))
+	actual, _ := core.GetInt("count")
+	assert.Equal(t, int64(2), actual)
+	assert.Equal(t, SourceCommandLine, core.Source("count"))
+	assert.EqualError(t, core.Set("count", "abc"), "flagset set failed parse error")
+	assert.EqualError(t, core.Set("missing", "abc"), "flagset set failed no such flag -missing")
+}
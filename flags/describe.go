@@ -0,0 +1,119 @@
+package flags
+
+import (
+	"net"
+	"net/url"
+	"regexp"
+	"time"
+)
+
+// FlagDescriptor describes a defined flag, which allows help handlers, documentation
+// generators, and completion to render flags without parsing the flag usage.
+type FlagDescriptor struct {
+	// Name is the name used to reference the flag.
+	Name string
+	// Shorthand is the single character name of the flag, which is only used by a PosixFlagSet.
+	Shorthand string
+	// Aliases are the alternate names of the flag.
+	Aliases []string
+	// Type is the name of the flag value type, such as string or duration.
+	Type string
+	// Default is the default value of the flag, expressed as a string.
+	Default string
+	// Usage is the flag usage string.
+	Usage string
+	// EnvVars are the environment variables bound to the flag.
+	EnvVars []string
+	// Choices are the values the flag accepts, or empty if the flag accepts any value.
+	Choices []string
+	// Deprecated is the deprecation message, or empty if the flag is not deprecated.
+	Deprecated string
+	// Required is true if the flag must be set.
+	Required bool
+	// Hidden is true if the flag is omitted from the flag usage.
+	Hidden bool
+	// Local is true if the flag is not inherited by sub routers and sub commands.
+	Local bool
+	// Negatable is true if the flag can be set to false using its name with a no- prefix.
+	Negatable bool
+	// Secret is true if the flag value is redacted whenever it is displayed.
+	Secret bool
+	// Path is the route path of the flagset the flag was defined on, which is empty for global flags.
+	Path []string
+	// Value is the flag value.
+	Value Value
+}
+
+// newFlagDescriptor returns the descriptor for the flag using its metadata.
+func newFlagDescriptor(name string, value Value, defValue, usage string, meta *flagMeta, envPrefix string) FlagDescriptor {
+	descriptor := FlagDescriptor{
+		Name:       name,
+		Aliases:    append([]string{}, meta.aliases...),
+		Type:       typeName(value),
+		Default:    defValue,
+		Usage:      usage,
+		EnvVars:    envVars(meta, envPrefix, name),
+		Deprecated: meta.deprecated[name],
+		Required:   meta.required,
+		Hidden:     meta.hidden,
+		Local:      meta.local,
+		Negatable:  meta.negatable,
+		Secret:     isSecret(value),
+		Path:       append([]string{}, meta.path...),
+		Value:      value,
+	}
+	if choiceValue, ok := value.(ChoiceValue); ok {
+		descriptor.Choices = choiceValue.Choices()
+	}
+	return descriptor
+}
+
+// typeName returns the name of the flag value type, such as string or duration.
+func typeName(value Value) string {
+	switch value.(type) {
+	case *countValue:
+		return "count"
+	case *byteSizeValue:
+		return "size"
+	case *pathValue:
+		return "path"
+	case *timeValue:
+		return "time"
+	case *secretValue:
+		return "secret"
+	}
+
+	switch value.Get().(type) {
+	case bool:
+		return "bool"
+	case int64:
+		return "int"
+	case uint64:
+		return "uint"
+	case string:
+		return "string"
+	case float64:
+		return "float"
+	case time.Duration:
+		return "duration"
+	case []string:
+		return "strings"
+	case []int64:
+		return "ints"
+	case []uint64:
+		return "uints"
+	case []float64:
+		return "floats"
+	case map[string]string:
+		return "key=value"
+	case net.IP:
+		return "ip"
+	case net.IPNet:
+		return "ipnet"
+	case *url.URL:
+		return "url"
+	case *regexp.Regexp:
+		return "regexp"
+	}
+	return "value"
+}
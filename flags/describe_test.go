@@ -0,0 +1,63 @@
+package flags
+
+import (
+	"net"
+	"net/url"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_typeName(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		input    Value
+		expected string
+	}{
+		{name: "bool", input: newBoolValue(false), expected: "bool"},
+		{name: "count", input: newCountValue(), expected: "count"},
+		{name: "enum", input: newEnumValue("a", []string{"a", "b"}), expected: "string"},
+		{name: "strings", input: &StringArrayFlag{}, expected: "strings"},
+		{name: "ints", input: newIntSliceValue(nil), expected: "ints"},
+		{name: "size", input: newByteSizeValue(0), expected: "size"},
+		{name: "time", input: newTimeValue(time.Time{}, time.RFC3339), expected: "time"},
+		{name: "ip", input: newIPValue(net.IP{}), expected: "ip"},
+		{name: "url", input: newURLValue(&url.URL{}), expected: "url"},
+		{name: "regexp", input: newRegexpValue(regexp.MustCompile("a")), expected: "regexp"},
+		{name: "string map", input: newStringMapValue(nil), expected: "key=value"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, typeName(test.input))
+		})
+	}
+}
+
+func Test_newFlagDescriptor(t *testing.T) {
+	meta := &flagMeta{
+		required: true,
+		envVars:  []string{"OUTPUT"},
+		aliases:  []string{"o"},
+		path:     []string{"users"},
+	}
+	meta.deprecate("output", "use -format instead")
+	value := newEnumValue("json", []string{"json", "yaml"})
+
+	assert.Equal(t, FlagDescriptor{
+		Name:       "output",
+		Aliases:    []string{"o"},
+		Type:       "string",
+		Default:    "json",
+		Usage:      "the output format",
+		EnvVars:    []string{"OUTPUT", "CLI_OUTPUT"},
+		Choices:    []string{"json", "yaml"},
+		Deprecated: "use -format instead",
+		Required:   true,
+		Path:       []string{"users"},
+		Value:      value,
+	}, newFlagDescriptor("output", value, "json", "the output format", meta, "CLI_"))
+}
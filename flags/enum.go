@@ -0,0 +1,70 @@
+package flags
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ChoiceValue is implemented by flag values that only accept a fixed set of values,
+// which allows help and completion handlers to list the valid choices for a flag.
+type ChoiceValue interface {
+	Value
+	// Choices returns the values the flag accepts.
+	Choices() []string
+}
+
+type enumValue struct {
+	value   string
+	choices []string
+}
+
+// newEnumValue returns a new enum value, which will panic if the default value is not one of the choices.
+func newEnumValue(defaultValue string, choices []string) *enumValue {
+	if len(choices) == 0 {
+		panic("enum flag requires at least one choice")
+	}
+	value := &enumValue{choices: append([]string{}, choices...)}
+	if defaultValue != "" && !value.valid(defaultValue) {
+		panic(fmt.Sprintf("enum default value %q is not one of: %s", defaultValue, strings.Join(choices, ", ")))
+	}
+	value.value = defaultValue
+	return value
+}
+
+func (value *enumValue) valid(s string) bool {
+	for _, choice := range value.choices {
+		if s == choice {
+			return true
+		}
+	}
+	return false
+}
+
+func (value *enumValue) Set(s string) error {
+	if !value.valid(s) {
+		return fmt.Errorf("must be one of: %s", strings.Join(value.choices, ", "))
+	}
+	value.value = s
+	return nil
+}
+
+// reset sets the default value, which may be empty rather than one of the choices.
+func (value *enumValue) reset(defValue string) error {
+	value.value = defValue
+	return nil
+}
+
+func (value *enumValue) Get() interface{} { return value.value }
+
+func (value *enumValue) String() string { return value.value }
+
+func (value *enumValue) Choices() []string { return append([]string{}, value.choices...) }
+
+// choicesUsage returns the valid choices for the flag value to be included in the flag usage.
+func choicesUsage(value interface{}) string {
+	choiceValue, ok := value.(ChoiceValue)
+	if !ok {
+		return ""
+	}
+	return fmt.Sprintf(" (one of: %s)", strings.Join(choiceValue.Choices(), ", "))
+}
@@ -0,0 +1,55 @@
+package flags
+
+import (
+	"flag"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Validate the enum value matches the standard golang flag library Getter interface
+var _ flag.Getter = newEnumValue("", []string{"a"})
+
+// Validate the enum value matches the ChoiceValue interface
+var _ ChoiceValue = newEnumValue("", []string{"a"})
+
+func Test_enumValue(t *testing.T) {
+	value := newEnumValue("json", []string{"json", "yaml", "table"})
+	assert.Equal(t, "json", value.Get())
+	assert.Equal(t, "json", value.String())
+	assert.Equal(t, []string{"json", "yaml", "table"}, value.Choices())
+
+	assert.Nil(t, value.Set("table"))
+	assert.Equal(t, "table", value.Get())
+
+	assert.EqualError(t, value.Set("xml"), "must be one of: json, yaml, table")
+	assert.Equal(t, "table", value.Get())
+
+	assert.EqualError(t, value.Set("JSON"), "must be one of: json, yaml, table")
+
+	t.Run("choices copied", func(t *testing.T) {
+		choices := []string{"a", "b"}
+		value := newEnumValue("", choices)
+		choices[0] = "c"
+		value.Choices()[1] = "d"
+		assert.Equal(t, []string{"a", "b"}, value.Choices())
+		assert.Equal(t, "", value.String())
+	})
+
+	t.Run("no choices", func(t *testing.T) {
+		testPanic(t, func() {
+			newEnumValue("", nil)
+		}, "enum flag requires at least one choice")
+	})
+
+	t.Run("invalid default", func(t *testing.T) {
+		testPanic(t, func() {
+			newEnumValue("xml", []string{"json", "yaml"})
+		}, "enum default value \"xml\" is not one of: json, yaml")
+	})
+}
+
+func Test_choicesUsage(t *testing.T) {
+	assert.Equal(t, " (one of: a, b)", choicesUsage(newEnumValue("a", []string{"a", "b"})))
+	assert.Equal(t, "", choicesUsage(newStringValue("a")))
+}
@@ -0,0 +1,61 @@
+package flags
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/evilmonkeyinc/golang-cli/errors"
+)
+
+// lookupEnv retrieves the value of the environment variable, and can be replaced for testing.
+var lookupEnv = os.LookupEnv
+
+// EnvName returns the environment variable name for the flag, using the specified prefix.
+//
+// The name is uppercase, with any character that is not a letter or a digit replaced with an underscore.
+func EnvName(prefix, flagName string) string {
+	return strings.Map(func(r rune) rune {
+		if (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return '_'
+	}, strings.ToUpper(prefix+flagName))
+}
+
+// envVars returns the environment variables bound to the flag, including
+// the automatic environment variable if a prefix has been set.
+func envVars(meta *flagMeta, prefix, flagName string) []string {
+	names := append([]string{}, meta.envVars...)
+	if prefix != "" {
+		names = append(names, EnvName(prefix, flagName))
+	}
+	return names
+}
+
+// envUsage returns the environment variables to display in the flag usage.
+func envUsage(names []string) string {
+	if len(names) == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" [$%s]", strings.Join(names, ", $"))
+}
+
+// applyEnv sets the flag value from the first environment variable that has been set,
+// unless the flag value has already been set from the command-line or environment variables.
+func applyEnv(meta *flagMeta, names []string, value flag.Value, flagName string) error {
+	if meta.source >= SourceEnvironment {
+		return nil
+	}
+	for _, name := range names {
+		if envValue, ok := lookupEnv(name); ok {
+			if err := value.Set(envValue); err != nil {
+				return errors.FlagsetParseFailed(fmt.Sprintf("invalid value %q for flag %s from $%s: %v", envValue, flagName, name, err))
+			}
+			meta.source = SourceEnvironment
+			return nil
+		}
+	}
+	return nil
+}
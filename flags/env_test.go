@@ -0,0 +1,66 @@
+package flags
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_EnvName(t *testing.T) {
+	assert.Equal(t, "MYCLI_USER_NAME", EnvName("MYCLI_", "user-name"))
+	assert.Equal(t, "MYCLI_USER_NAME", EnvName("mycli_", "user.name"))
+	assert.Equal(t, "PORT", EnvName("", "port"))
+}
+
+func Test_envVars(t *testing.T) {
+	meta := &flagMeta{envVars: []string{"PORT"}}
+	assert.Equal(t, []string{"PORT"}, envVars(meta, "", "port"))
+	assert.Equal(t, []string{"PORT", "MYCLI_PORT"}, envVars(meta, "MYCLI_", "port"))
+}
+
+func Test_envUsage(t *testing.T) {
+	assert.Equal(t, "", envUsage(nil))
+	assert.Equal(t, " [$PORT]", envUsage([]string{"PORT"}))
+	assert.Equal(t, " [$PORT, $MYCLI_PORT]", envUsage([]string{"PORT", "MYCLI_PORT"}))
+}
+
+func Test_applyEnv(t *testing.T) {
+	t.Setenv("TEST_FIRST", "1")
+	t.Setenv("TEST_SECOND", "2")
+	t.Setenv("TEST_INVALID", "abc")
+
+	t.Run("first set", func(t *testing.T) {
+		meta := &flagMeta{}
+		value := newIntValue(0)
+		err := applyEnv(meta, []string{"TEST_UNSET", "TEST_SECOND", "TEST_FIRST"}, value, "-count")
+		assert.Nil(t, err)
+		assert.Equal(t, int64(2), value.Get())
+		assert.Equal(t, SourceEnvironment, meta.source)
+	})
+
+	t.Run("none set", func(t *testing.T) {
+		meta := &flagMeta{}
+		value := newIntValue(0)
+		err := applyEnv(meta, []string{"TEST_UNSET"}, value, "-count")
+		assert.Nil(t, err)
+		assert.Equal(t, int64(0), value.Get())
+		assert.Equal(t, SourceDefault, meta.source)
+	})
+
+	t.Run("already set", func(t *testing.T) {
+		meta := &flagMeta{source: SourceCommandLine}
+		value := newIntValue(5)
+		err := applyEnv(meta, []string{"TEST_FIRST"}, value, "-count")
+		assert.Nil(t, err)
+		assert.Equal(t, int64(5), value.Get())
+		assert.Equal(t, SourceCommandLine, meta.source)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		meta := &flagMeta{}
+		value := newIntValue(0)
+		err := applyEnv(meta, []string{"TEST_INVALID"}, value, "-count")
+		assert.EqualError(t, err, "flagset parse failed invalid value \"abc\" for flag -count from $TEST_INVALID: parse error")
+		assert.Equal(t, SourceDefault, meta.source)
+	})
+}
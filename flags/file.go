@@ -0,0 +1,64 @@
+package flags
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// DefaultFileLimit is the default maximum size, in bytes, of a flag value read from a file or the input.
+const DefaultFileLimit = 1 << 20
+
+// fileValuePrefix is the prefix used to read a flag value from a file, such as @/run/secrets/password,
+// or from the input using @-. The prefix can be escaped using @@ to set a value starting with @.
+const fileValuePrefix = "@"
+
+// fileSettings contains the settings used to read flag values from files or the input.
+type fileSettings struct {
+	// enabled is true if every flag can be read from a file, rather than only the flags marked using FileValue
+	enabled bool
+	limit   int64
+	input   io.Reader
+}
+
+// resolve returns the flag value, which will be read from a file or the input if the value
+// starts with the @ prefix and file values have been enabled for the flag.
+func (settings fileSettings) resolve(meta *flagMeta, displayName, value string) (string, error) {
+	if !(settings.enabled || meta.fileValue) || !strings.HasPrefix(value, fileValuePrefix) {
+		return value, nil
+	}
+	path := strings.TrimPrefix(value, fileValuePrefix)
+	if strings.HasPrefix(path, fileValuePrefix) {
+		return path, nil
+	}
+
+	limit := settings.limit
+	if limit <= 0 {
+		limit = DefaultFileLimit
+	}
+
+	var reader io.Reader
+	if path == "-" {
+		reader = settings.input
+		if reader == nil {
+			reader = os.Stdin
+		}
+	} else {
+		file, err := os.Open(path)
+		if err != nil {
+			return "", fmt.Errorf("failed to read value for flag %s: %w", displayName, err)
+		}
+		defer file.Close()
+		reader = file
+	}
+
+	content, err := io.ReadAll(io.LimitReader(reader, limit+1))
+	if err != nil {
+		return "", fmt.Errorf("failed to read value for flag %s: %w", displayName, err)
+	}
+	if int64(len(content)) > limit {
+		return "", fmt.Errorf("value for flag %s exceeds the limit of %d bytes", displayName, limit)
+	}
+	return strings.TrimRight(string(content), "\r\n"), nil
+}
@@ -0,0 +1,89 @@
+package flags
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_fileSettings_resolve(t *testing.T) {
+	dir := t.TempDir()
+	secretPath := filepath.Join(dir, "secret")
+	assert.Nil(t, os.WriteFile(secretPath, []byte("s3cret\n"), 0600))
+
+	tests := []struct {
+		name     string
+		settings fileSettings
+		meta     *flagMeta
+		input    string
+		expected string
+		err      string
+	}{
+		{
+			name:     "not enabled",
+			meta:     &flagMeta{},
+			input:    "@" + secretPath,
+			expected: "@" + secretPath,
+		},
+		{
+			name:     "not prefixed",
+			meta:     &flagMeta{fileValue: true},
+			input:    "value",
+			expected: "value",
+		},
+		{
+			name:     "file",
+			meta:     &flagMeta{fileValue: true},
+			input:    "@" + secretPath,
+			expected: "s3cret",
+		},
+		{
+			name:     "enabled for all flags",
+			settings: fileSettings{enabled: true},
+			meta:     &flagMeta{},
+			input:    "@" + secretPath,
+			expected: "s3cret",
+		},
+		{
+			name:     "escaped",
+			meta:     &flagMeta{fileValue: true},
+			input:    "@@value",
+			expected: "@value",
+		},
+		{
+			name:     "input",
+			settings: fileSettings{input: strings.NewReader("from input\r\n\r\n")},
+			meta:     &flagMeta{fileValue: true},
+			input:    "@-",
+			expected: "from input",
+		},
+		{
+			name:  "missing file",
+			meta:  &flagMeta{fileValue: true},
+			input: "@" + filepath.Join(dir, "missing"),
+			err:   "failed to read value for flag -password: open " + filepath.Join(dir, "missing") + ": no such file or directory",
+		},
+		{
+			name:     "limit",
+			settings: fileSettings{limit: 4},
+			meta:     &flagMeta{fileValue: true},
+			input:    "@" + secretPath,
+			err:      "value for flag -password exceeds the limit of 4 bytes",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual, err := test.settings.resolve(test.meta, "-password", test.input)
+			if test.err != "" {
+				assert.EqualError(t, err, test.err)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, test.expected, actual)
+			}
+		})
+	}
+}
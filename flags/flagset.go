@@ -4,6 +4,11 @@ import (
 	"bytes"
 	goerrors "errors"
 	"flag"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"regexp"
 	"strings"
 	"time"
 
@@ -24,10 +29,54 @@ type FlagSet interface {
 	Parse(args []string) ([]string, error)
 	// Parsed returns true if Parse has been called.
 	Parsed() bool
+	// SetInterspersed sets whether Parse will allow flags to be interspersed with non-flag arguments.
+	//
+	// When true, Parse will extract flags from anywhere in the argument list until the -- terminator.
+	SetInterspersed(interspersed bool)
+	// Interspersed returns true if Parse will allow flags to be interspersed with non-flag arguments.
+	Interspersed() bool
+	// SetEnvPrefix sets the prefix used to bind every flag to an environment variable,
+	// such as MYCLI_ binding the flag named user-name to MYCLI_USER_NAME.
+	SetEnvPrefix(prefix string)
+	// SetFileValues sets whether every flag value can be read from a file, rather than
+	// only the flags marked using FileValue.
+	SetFileValues(enabled bool)
+	// SetFileLimit sets the maximum size, in bytes, of a flag value read from a file or the input,
+	// which defaults to DefaultFileLimit.
+	SetFileLimit(limit int64)
+	// SetInput sets the reader used to read flag values using @-, which defaults to os.Stdin.
+	SetInput(reader io.Reader)
+	// SetConfig sets the configuration source used to set any flag that has not been set
+	// on the command-line or from environment variables, and applies it to the defined flags.
+	//
+	// Sub flagsets will look up configuration values using their route path, such as users.add.role,
+	// before falling back to the parent paths.
+	SetConfig(source ConfigSource) error
+	// Config returns the configuration source, or nil if one has not been set.
+	Config() ConfigSource
+
+	// Validate validates the flag values using the required flags and flag validators,
+	// and will return a FlagValidationError if any of the flags fail validation.
+	// Must be called after Parse.
+	Validate() error
+	// Warnings returns the warnings produced by the last call to Parse, such as the use of a deprecated flag.
+	Warnings() []string
+	// Snapshot saves the current state of the flags so they can be restored using Reset,
+	// including the state of any flags defined by sub flagsets after the snapshot was taken.
+	Snapshot()
+	// Reset restores the flags to the state saved by Snapshot, including any flags
+	// defined by sub flagsets since the snapshot was taken, the flag values are
+	// restored to their default values.
+	Reset()
 
 	// DefaultUsage returns a usage message showing the default
 	// settings of all defined command-line flags.
 	DefaultUsage() string
+	// VisitAll visits the defined flags in lexicographical order, calling fn for each.
+	VisitAll(fn func(name string, value Value))
+	// Describe returns the descriptors of the defined flags in lexicographical order,
+	// including any hidden flags.
+	Describe() []FlagDescriptor
 }
 
 // FlagDefiner allows you to define the flags managed by the flag set
@@ -46,10 +95,81 @@ type FlagDefiner interface {
 	Float(name string, defaultValue float64, usage string)
 	// Duration defines a time.Duration flag with specified name, default value, and usage string.
 	Duration(name string, defaultValue time.Duration, usage string)
+	// Count defines an int64 flag with specified name and usage string, which does not require
+	// an argument and is incremented each time it is used, such as -v -v -v. The flag is retrieved using GetInt.
+	Count(name string, usage string)
+	// Enum defines a string flag with specified name, default value, choices, and usage string.
+	// The flag can only be set to one of the choices, which are included in the flag usage.
+	Enum(name string, defaultValue string, choices []string, usage string)
+	// IntSlice defines a int64 slice flag with specified name, default value, and usage string.
+	// The flag can be used multiple times or with a comma separated list.
+	IntSlice(name string, defaultValue []int64, usage string)
+	// UintSlice defines a uint64 slice flag with specified name, default value, and usage string.
+	// The flag can be used multiple times or with a comma separated list.
+	UintSlice(name string, defaultValue []uint64, usage string)
+	// FloatSlice defines a float64 slice flag with specified name, default value, and usage string.
+	// The flag can be used multiple times or with a comma separated list.
+	FloatSlice(name string, defaultValue []float64, usage string)
+	// StringMap defines a map[string]string flag with specified name, default value, and usage string.
+	// The flag is set using key=value pairs, and can be used multiple times or with a comma separated list.
+	StringMap(name string, defaultValue map[string]string, usage string)
+	// Time defines a time.Time flag with specified name, default value, layout, and usage string.
+	// The layout is used to parse the flag value, as described by time.Parse.
+	Time(name string, defaultValue time.Time, layout string, usage string)
+	// IP defines a net.IP flag with specified name, default value, and usage string.
+	IP(name string, defaultValue net.IP, usage string)
+	// IPNet defines a net.IPNet flag with specified name, default value, and usage string.
+	// The flag is set using CIDR notation, such as 192.168.0.0/16.
+	IPNet(name string, defaultValue net.IPNet, usage string)
+	// URL defines a *url.URL flag with specified name, default value, and usage string.
+	URL(name string, defaultValue *url.URL, usage string)
+	// ByteSize defines a uint64 flag with specified name, default value, and usage string.
+	// The flag is set using a byte size, such as 512, 1.5GB, or 10MiB, and is retrieved using GetUint.
+	ByteSize(name string, defaultValue uint64, usage string)
+	// Regexp defines a *regexp.Regexp flag with specified name, default value, and usage string.
+	Regexp(name string, defaultValue *regexp.Regexp, usage string)
+	// Secret defines a string flag with specified name and usage string, whose value is redacted
+	// whenever it is displayed. The flag is retrieved using GetString.
+	Secret(name string, usage string)
+	// Path defines a string flag with specified name, default value, path check, and usage string.
+	// The path check is performed whenever the flag is set, and the flag is retrieved using GetString.
+	Path(name string, defaultValue string, check PathCheck, usage string)
 	// Var defines a flag with the specified name and usage string.
 	// The type and value of the flag are represented by the first argument,
 	// of type Value, which typically holds a user-defined implementation of Value.
 	Var(value Value, name, usage string)
+	// Required marks the named flag as required, validation will fail if the flag has not been set.
+	Required(name string)
+	// Validators adds validators to the named flag, which will be evaluated during validation if the flag has been set.
+	Validators(name string, validators ...Validator)
+	// ExactlyOneOf declares that exactly one of the named flags must be set.
+	ExactlyOneOf(names ...string)
+	// AtMostOneOf declares that no more than one of the named flags can be set.
+	AtMostOneOf(names ...string)
+	// AllOrNone declares that if any of the named flags are set then all of them must be set.
+	AllOrNone(names ...string)
+	// Env binds the named flag to one or more environment variables, which will be used
+	// to set the flag value if it has not been set on the command-line.
+	Env(name string, envVars ...string)
+	// Local marks the named flag as local to the flagset it is defined on, so it will not be inherited
+	// by sub routers and sub commands. Flags that are not marked as local are persistent and inherited.
+	Local(name string)
+	// Alias adds one or more alternate names for the named flag, which share the flag value.
+	Alias(name string, aliases ...string)
+	// DeprecatedAlias adds an alternate name for the named flag, which will produce a warning
+	// containing the message, such as "use -output instead", whenever the alias is used.
+	DeprecatedAlias(name string, alias string, message string)
+	// Hidden marks the named flag as hidden, so it can still be used but is omitted from the flag usage.
+	Hidden(name string)
+	// Deprecated marks the named flag as deprecated, which will produce a warning containing
+	// the message, such as "use -output instead", whenever the flag is used.
+	Deprecated(name string, message string)
+	// FileValue allows the named flag value to be read from a file on the command-line using @path,
+	// such as -password @/run/secrets/password, or from the input using @-.
+	// Any trailing newlines are removed from the value.
+	FileValue(name string)
+	// Negatable allows the named bool flag to be set to false using its name with a no- prefix, such as -no-color.
+	Negatable(name string)
 }
 
 //FlagValues allows you to retreive flags
@@ -70,8 +190,31 @@ type FlagValues interface {
 	GetFloat(name string) (float64, bool)
 	// GetDuration returns the value of a named flag as a time.Duration.
 	GetDuration(name string) (time.Duration, bool)
+	// GetIntSlice returns the value of a named flag as a int64 slice.
+	GetIntSlice(name string) ([]int64, bool)
+	// GetUintSlice returns the value of a named flag as a uint64 slice.
+	GetUintSlice(name string) ([]uint64, bool)
+	// GetFloatSlice returns the value of a named flag as a float64 slice.
+	GetFloatSlice(name string) ([]float64, bool)
+	// GetStringMap returns the value of a named flag as a map[string]string.
+	GetStringMap(name string) (map[string]string, bool)
+	// GetTime returns the value of a named flag as a time.Time.
+	GetTime(name string) (time.Time, bool)
+	// GetIP returns the value of a named flag as a net.IP.
+	GetIP(name string) (net.IP, bool)
+	// GetIPNet returns the value of a named flag as a net.IPNet.
+	GetIPNet(name string) (net.IPNet, bool)
+	// GetURL returns the value of a named flag as a *url.URL.
+	GetURL(name string) (*url.URL, bool)
+	// GetRegexp returns the value of a named flag as a *regexp.Regexp.
+	GetRegexp(name string) (*regexp.Regexp, bool)
 	// Set sets the value of the named flag.
 	Set(name, value string) error
+	// Source returns the source of the named flag value.
+	Source(name string) Source
+	// Changed returns true if the named flag has been set on the command-line,
+	// from environment variables, or from a configuration source, rather than using its default value.
+	Changed(name string) bool
 }
 
 // Value is the interface to the dynamic value stored in a flag.
@@ -91,17 +234,25 @@ func NewDefaultFlagSet() *DefaultFlagSet {
 
 // NewDefaultFlagSetWithBase returns a new DefaultFlagSet using the specified flag.FlagSet as a base.
 func NewDefaultFlagSetWithBase(flagSet *flag.FlagSet) *DefaultFlagSet {
-	return &DefaultFlagSet{
+	defaultFlagSet := &DefaultFlagSet{
 		set: flagSet,
 	}
+	if flagSet != nil {
+		flagSet.VisitAll(func(f *flag.Flag) {
+			defaultFlagSet.define(f.Name)
+		})
+	}
+	return defaultFlagSet
 }
 
 // DefaultFlagSet is the basic FlagSet implementation using the standard golang flag library
 type DefaultFlagSet struct {
+	flagCore
 	set *flag.FlagSet
 }
 
 func (flagSet *DefaultFlagSet) setup() {
+	flagSet.flagCore.setup()
 	if flagSet.set == nil {
 		flagSet.set = flag.NewFlagSet("", flag.ContinueOnError)
 		flagSet.set.SetOutput(&bytes.Buffer{})
@@ -116,13 +267,16 @@ func (flagSet *DefaultFlagSet) SubFlagSet(name string) FlagSet {
 	newFlagSet.SetOutput(flagSet.set.Output())
 	newFlagSet.Usage = flagSet.set.Usage
 
+	subFlagSet := &DefaultFlagSet{
+		flagCore: flagSet.sub(name),
+		set:      newFlagSet,
+	}
 	flagSet.set.VisitAll(func(f *flag.Flag) {
-		newFlagSet.Var(f.Value, f.Name, f.Usage)
+		if subFlagSet.lookup(f.Name) != nil {
+			newFlagSet.Var(f.Value, f.Name, f.Usage)
+		}
 	})
-
-	return &DefaultFlagSet{
-		set: newFlagSet,
-	}
+	return subFlagSet
 }
 
 // Parse parses flag definitions from the argument list, which should not include the command name, and return remaining, non-flag, arguments.
@@ -130,66 +284,200 @@ func (flagSet *DefaultFlagSet) SubFlagSet(name string) FlagSet {
 // The return value will be ErrHelp if -help was set but not defined.
 func (flagSet *DefaultFlagSet) Parse(args []string) ([]string, error) {
 	flagSet.setup()
-	if err := flagSet.set.Parse(args); err != nil {
-		if goerrors.Is(err, flag.ErrHelp) {
-			return flagSet.set.Args(), errors.HelpRequested("flags")
+	flagSet.parsed = true
+	flagSet.warnings = nil
+	args, err := flagSet.expandArgs(args)
+	if err != nil {
+		return args, err
+	}
+	if !flagSet.interspersed {
+		return flagSet.parse(args)
+	}
+
+	// separate the flags, and their values, from the non-flag arguments
+	// so that the standard flag library can parse them as a single list
+	flagArgs := []string{}
+	positional := []string{}
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			positional = append(positional, args[i+1:]...)
+			break
+		}
+		if len(arg) < 2 || arg[0] != '-' {
+			positional = append(positional, arg)
+			continue
+		}
+
+		flagArgs = append(flagArgs, arg)
+		name := strings.TrimPrefix(arg[1:], "-")
+		if strings.Contains(name, "=") {
+			continue
+		}
+		if f := flagSet.set.Lookup(name); f != nil && i+1 < len(args) {
+			if isBoolFlag(f.Value) {
+				continue
+			}
+			i++
+			flagArgs = append(flagArgs, args[i])
 		}
-		return flagSet.set.Args(), errors.FlagsetParseFailed(err.Error())
 	}
-	return flagSet.set.Args(), nil
+
+	remaining, err := flagSet.parse(flagArgs)
+	return append(positional, remaining...), err
 }
 
-// Parsed returns true if Parse has been called.
-func (flagSet *DefaultFlagSet) Parsed() bool {
-	flagSet.setup()
-	return flagSet.set.Parsed()
+// expandArgs replaces any negated flags, such as -no-color, with the equivalent flag set to false,
+// replaces any bundled count flags, such as -vvv, with the individual flags, and reads any flag
+// values from files, such as -password @/run/secrets/password, so they can be parsed by the
+// standard golang flag library.
+func (flagSet *DefaultFlagSet) expandArgs(args []string) ([]string, error) {
+	expanded := make([]string, 0, len(args))
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			return append(expanded, args[i:]...), nil
+		}
+		if len(arg) < 2 || arg[0] != '-' {
+			if !flagSet.interspersed {
+				return append(expanded, args[i:]...), nil
+			}
+			expanded = append(expanded, arg)
+			continue
+		}
+
+		name := strings.TrimPrefix(arg[1:], "-")
+		if strings.HasPrefix(name, negationPrefix) && flagSet.set.Lookup(name) == nil {
+			target := strings.TrimPrefix(name, negationPrefix)
+			if f := flagSet.lookup(target); f != nil && isBoolFlag(f.value) && flagSet.meta(target).negatable {
+				expanded = append(expanded, "-"+target+"=false")
+				continue
+			}
+		}
+		if bundle, ok := flagSet.countBundle(arg); ok {
+			expanded = append(expanded, bundle...)
+			continue
+		}
+
+		if index := strings.Index(name, "="); index >= 0 {
+			value, err := flagSet.resolveFile(name[:index], name[index+1:])
+			if err != nil {
+				return args, err
+			}
+			expanded = append(expanded, "-"+name[:index]+"="+value)
+			continue
+		}
+		expanded = append(expanded, arg)
+		if f := flagSet.set.Lookup(name); f != nil && !isBoolFlag(f.Value) && i+1 < len(args) {
+			i++
+			value, err := flagSet.resolveFile(name, args[i])
+			if err != nil {
+				return args, err
+			}
+			expanded = append(expanded, value)
+		}
+	}
+	return expanded, nil
 }
 
-// Set sets the value of the named flag.
-func (flagSet *DefaultFlagSet) Set(name, value string) error {
-	flagSet.setup()
-	if err := flagSet.set.Set(name, value); err != nil {
-		return errors.FlagsetSetFailed(err.Error())
+// countBundle returns the individual flags for a bundle of single character count flags, such as -vvv,
+// and false if the argument is a defined flag or contains anything other than count flags.
+func (flagSet *DefaultFlagSet) countBundle(arg string) ([]string, bool) {
+	name := arg[1:]
+	if len(name) < 2 || name[0] == '-' || flagSet.lookup(name) != nil {
+		return nil, false
+	}
+	bundle := make([]string, 0, len(name))
+	for _, r := range name {
+		f := flagSet.lookup(string(r))
+		if f == nil {
+			return nil, false
+		}
+		if _, isCount := f.value.(*countValue); !isCount {
+			return nil, false
+		}
+		bundle = append(bundle, "-"+string(r))
+	}
+	return bundle, true
+}
+
+// resolveFile returns the value for the named flag, which will be read from a file
+// or the input if file values have been enabled for the flag.
+func (flagSet *DefaultFlagSet) resolveFile(name, value string) (string, error) {
+	f := flagSet.lookup(name)
+	if f == nil {
+		return value, nil
 	}
+	return flagSet.fileValue(f, "-"+name, value)
+}
 
-	return nil
+// parse parses the flag definitions using the standard golang flag library.
+func (flagSet *DefaultFlagSet) parse(args []string) ([]string, error) {
+	err := flagSet.set.Parse(args)
+	flagSet.set.Visit(func(f *flag.Flag) {
+		if defined := flagSet.lookup(f.Name); defined != nil {
+			flagSet.setByName(defined, f.Name, "-"+f.Name)
+		}
+	})
+	if err != nil {
+		if goerrors.Is(err, flag.ErrHelp) {
+			return flagSet.set.Args(), errors.HelpRequested("flags")
+		}
+		return flagSet.set.Args(), errors.FlagsetParseFailed(err.Error())
+	}
+	if err := flagSet.applySources(); err != nil {
+		return flagSet.set.Args(), err
+	}
+	return flagSet.set.Args(), nil
 }
 
-// Get returns the value of the named flag.
-func (flagSet *DefaultFlagSet) Get(name string) interface{} {
+// Alias adds one or more alternate names for the named flag, which share the flag value.
+// Alias will panic if the flag has not been defined or if an alias has already been defined.
+func (flagSet *DefaultFlagSet) Alias(name string, aliases ...string) {
 	flagSet.setup()
-	lookedUp := flagSet.set.Lookup(name)
-	if lookedUp == nil {
-		return nil
+	f := flagSet.lookup(name)
+	if f == nil {
+		panic(fmt.Sprintf("flag %q is not defined", name))
 	}
-	if getter, ok := lookedUp.Value.(flag.Getter); ok {
-		return getter.Get()
+	for _, alias := range aliases {
+		flagSet.set.Var(f.value, alias, f.usage)
+		flagSet.alias(f.name, alias)
 	}
-	return lookedUp.Value.String()
+}
+
+// DeprecatedAlias adds an alternate name for the named flag, which will produce a warning
+// containing the message, such as "use -output instead", whenever the alias is used.
+func (flagSet *DefaultFlagSet) DeprecatedAlias(name string, alias string, message string) {
+	flagSet.Alias(name, alias)
+	flagSet.meta(name).deprecate(alias, message)
 }
 
 // Bool defines a bool flag with specified name, default value, and usage string.
 func (flagSet *DefaultFlagSet) Bool(name string, defaultValue bool, usage string) {
 	flagSet.setup()
 	flagSet.set.Bool(name, defaultValue, usage)
+	flagSet.define(name)
 }
 
 // Int defines a int64 flag with specified name, default value, and usage string.
 func (flagSet *DefaultFlagSet) Int(name string, defaultValue int64, usage string) {
 	flagSet.setup()
 	flagSet.set.Int64(name, defaultValue, usage)
+	flagSet.define(name)
 }
 
 // Uint defines a unit64 flag with specified name, default value, and usage string.
 func (flagSet *DefaultFlagSet) Uint(name string, defaultValue uint64, usage string) {
 	flagSet.setup()
 	flagSet.set.Uint64(name, defaultValue, usage)
+	flagSet.define(name)
 }
 
 // String defines a string flag with specified name, default value, and usage string.
 func (flagSet *DefaultFlagSet) String(name string, defaultValue string, usage string) {
 	flagSet.setup()
 	flagSet.set.String(name, defaultValue, usage)
+	flagSet.define(name)
 }
 
 // StringArray defines a string array flag with specified name, default value, and usage string.
@@ -207,115 +495,165 @@ func (flagSet *DefaultFlagSet) StringArray(name string, defaultValue []string, u
 func (flagSet *DefaultFlagSet) Float(name string, defaultValue float64, usage string) {
 	flagSet.setup()
 	flagSet.set.Float64(name, defaultValue, usage)
+	flagSet.define(name)
 }
 
 // Duration defines a time.Duration flag with specified name, default value, and usage string.
 func (flagSet *DefaultFlagSet) Duration(name string, defaultValue time.Duration, usage string) {
 	flagSet.setup()
 	flagSet.set.Duration(name, defaultValue, usage)
+	flagSet.define(name)
 }
 
-// Var defines a flag with the specified name and usage string.
-// The type and value of the flag are represented by the first argument,
-// of type Value, which typically holds a user-defined implementation of Value.
-func (flagSet *DefaultFlagSet) Var(value Value, name, usage string) {
-	flagSet.setup()
-	flagSet.set.Var(value, name, usage)
+// Count defines an int64 flag with specified name and usage string, which does not require
+// an argument and is incremented each time it is used, such as -v -v -v. Single character count
+// flags can also be bundled, such as -vvv. The flag is retrieved using GetInt.
+func (flagSet *DefaultFlagSet) Count(name string, usage string) {
+	flagSet.Var(newCountValue(), name, usage)
 }
 
-// GetBool returns the value of a named flag as a bool.
-func (flagSet *DefaultFlagSet) GetBool(name string) (bool, bool) {
-	value := flagSet.Get(name)
-	if value == nil {
-		return false, false
-	}
-	if boolValue, ok := value.(bool); ok {
-		return boolValue, true
-	}
-	return false, false
+// Enum defines a string flag with specified name, default value, choices, and usage string.
+// The flag can only be set to one of the choices, which are included in the flag usage.
+func (flagSet *DefaultFlagSet) Enum(name string, defaultValue string, choices []string, usage string) {
+	flagSet.Var(newEnumValue(defaultValue, choices), name, usage)
 }
 
-// GetInt returns the value of a named flag as a int64.
-func (flagSet *DefaultFlagSet) GetInt(name string) (int64, bool) {
-	value := flagSet.Get(name)
-	if value == nil {
-		return 0, false
-	}
-	if intValue, ok := value.(int64); ok {
-		return intValue, true
-	}
-	return 0, false
+// IntSlice defines a int64 slice flag with specified name, default value, and usage string.
+// The flag can be used multiple times or with a comma separated list.
+func (flagSet *DefaultFlagSet) IntSlice(name string, defaultValue []int64, usage string) {
+	flagSet.Var(newIntSliceValue(defaultValue), name, usage)
 }
 
-// GetUint returns the value of a named flag as a uint64.
-func (flagSet *DefaultFlagSet) GetUint(name string) (uint64, bool) {
-	value := flagSet.Get(name)
-	if value == nil {
-		return 0, false
-	}
-	if intValue, ok := value.(uint64); ok {
-		return intValue, true
-	}
-	return 0, false
+// UintSlice defines a uint64 slice flag with specified name, default value, and usage string.
+// The flag can be used multiple times or with a comma separated list.
+func (flagSet *DefaultFlagSet) UintSlice(name string, defaultValue []uint64, usage string) {
+	flagSet.Var(newUintSliceValue(defaultValue), name, usage)
 }
 
-// GetString returns the value of a named flag as a string.
-func (flagSet *DefaultFlagSet) GetString(name string) (string, bool) {
-	value := flagSet.Get(name)
-	if value == nil {
-		return "", false
-	}
-	if stringValue, ok := value.(string); ok {
-		return stringValue, true
-	}
-	return "", false
+// FloatSlice defines a float64 slice flag with specified name, default value, and usage string.
+// The flag can be used multiple times or with a comma separated list.
+func (flagSet *DefaultFlagSet) FloatSlice(name string, defaultValue []float64, usage string) {
+	flagSet.Var(newFloatSliceValue(defaultValue), name, usage)
 }
 
-// GetStringArray returns the value of a named flag as a string array.
-func (flagSet *DefaultFlagSet) GetStringArray(name string) ([]string, bool) {
-	value := flagSet.Get(name)
-	if value == nil {
-		return nil, false
-	}
-	if arrayValue, ok := value.([]string); ok {
-		return arrayValue, true
-	}
-	return nil, false
+// StringMap defines a map[string]string flag with specified name, default value, and usage string.
+// The flag is set using key=value pairs, and can be used multiple times or with a comma separated list.
+func (flagSet *DefaultFlagSet) StringMap(name string, defaultValue map[string]string, usage string) {
+	flagSet.Var(newStringMapValue(defaultValue), name, usage)
 }
 
-// GetFloat returns the value of a named flag as a float64.
-func (flagSet *DefaultFlagSet) GetFloat(name string) (float64, bool) {
-	value := flagSet.Get(name)
-	if value == nil {
-		return 0, false
-	}
-	if floatValue, ok := value.(float64); ok {
-		return floatValue, true
-	}
-	return 0, false
+// Time defines a time.Time flag with specified name, default value, layout, and usage string.
+// The layout is used to parse the flag value, as described by time.Parse.
+func (flagSet *DefaultFlagSet) Time(name string, defaultValue time.Time, layout string, usage string) {
+	flagSet.Var(newTimeValue(defaultValue, layout), name, usage)
 }
 
-// GetDuration returns the value of a named flag as a time.Duration.
-func (flagSet *DefaultFlagSet) GetDuration(name string) (time.Duration, bool) {
-	value := flagSet.Get(name)
-	if value == nil {
-		return time.Duration(0), false
-	}
-	if durationValue, ok := value.(time.Duration); ok {
-		return durationValue, true
+// IP defines a net.IP flag with specified name, default value, and usage string.
+func (flagSet *DefaultFlagSet) IP(name string, defaultValue net.IP, usage string) {
+	flagSet.Var(newIPValue(defaultValue), name, usage)
+}
+
+// IPNet defines a net.IPNet flag with specified name, default value, and usage string.
+// The flag is set using CIDR notation, such as 192.168.0.0/16.
+func (flagSet *DefaultFlagSet) IPNet(name string, defaultValue net.IPNet, usage string) {
+	flagSet.Var(newIPNetValue(defaultValue), name, usage)
+}
+
+// URL defines a *url.URL flag with specified name, default value, and usage string.
+func (flagSet *DefaultFlagSet) URL(name string, defaultValue *url.URL, usage string) {
+	flagSet.Var(newURLValue(defaultValue), name, usage)
+}
+
+// ByteSize defines a uint64 flag with specified name, default value, and usage string.
+// The flag is set using a byte size, such as 512, 1.5GB, or 10MiB, and is retrieved using GetUint.
+func (flagSet *DefaultFlagSet) ByteSize(name string, defaultValue uint64, usage string) {
+	flagSet.Var(newByteSizeValue(defaultValue), name, usage)
+}
+
+// Regexp defines a *regexp.Regexp flag with specified name, default value, and usage string.
+func (flagSet *DefaultFlagSet) Regexp(name string, defaultValue *regexp.Regexp, usage string) {
+	flagSet.Var(newRegexpValue(defaultValue), name, usage)
+}
+
+// Secret defines a string flag with specified name and usage string, whose value is redacted
+// whenever it is displayed. The flag is retrieved using GetString.
+func (flagSet *DefaultFlagSet) Secret(name string, usage string) {
+	flagSet.Var(new(secretValue), name, usage)
+}
+
+// Path defines a string flag with specified name, default value, path check, and usage string.
+// The path check is performed whenever the flag is set, and the flag is retrieved using GetString.
+func (flagSet *DefaultFlagSet) Path(name string, defaultValue string, check PathCheck, usage string) {
+	flagSet.Var(newPathValue(defaultValue, check), name, usage)
+}
+
+// Var defines a flag with the specified name and usage string.
+// The type and value of the flag are represented by the first argument,
+// of type Value, which typically holds a user-defined implementation of Value.
+func (flagSet *DefaultFlagSet) Var(value Value, name, usage string) {
+	flagSet.setup()
+	flagSet.set.Var(value, name, usage)
+	flagSet.define(name)
+}
+
+// define records the definition of the named flag once it has been added to the standard flagset.
+func (flagSet *DefaultFlagSet) define(name string) {
+	f := flagSet.set.Lookup(name)
+	value, ok := f.Value.(Value)
+	if !ok {
+		value = &getterValue{f.Value}
 	}
-	return time.Duration(0), false
+	flagSet.register(&definedFlag{
+		name:        name,
+		usage:       f.Usage,
+		value:       value,
+		defValue:    f.DefValue,
+		displayName: "-" + name,
+	})
+}
+
+// getterValue wraps a flag.Value that does not implement Get, such as a flag
+// defined on the base flag.FlagSet, so that Get returns the string value.
+type getterValue struct {
+	flag.Value
+}
+
+// Get returns the flag value
+func (value *getterValue) Get() interface{} {
+	return value.String()
 }
 
 // DefaultUsage returns a usage message showing the default
 // settings of all defined command-line flags.
 func (flagSet *DefaultFlagSet) DefaultUsage() string {
+	flagSet.setup()
 	buffer := &bytes.Buffer{}
 
-	existing := flagSet.set.Output()
-	flagSet.set.SetOutput(buffer)
-	flagSet.set.PrintDefaults()
-	flagSet.set.SetOutput(existing)
+	// the flags are copied to a new flagset so the aliases can be included in the
+	// flag name, and the choices, negation, and environment variables can be added
+	// to the flag usage, so they are all included in the standard output
+	usageSet := flag.NewFlagSet("", flag.ContinueOnError)
+	usageSet.SetOutput(buffer)
+	for _, f := range flagSet.sortedFlags() {
+		meta := flagSet.meta(f.name)
+		if meta.hidden {
+			continue
+		}
+		name := f.name + aliasNames(meta.aliases, func(alias string) string {
+			return "-" + alias
+		})
+		usage := f.usage
+		usage += choicesUsage(f.value)
+		usage += negateUsage(meta, "-"+negationPrefix+f.name)
+		usage += envUsage(envVars(meta, flagSet.envPrefix, f.name))
+		usageSet.Var(f.value, name, usage)
+		usageSet.Lookup(name).DefValue = f.defValue
+	}
+	usageSet.PrintDefaults()
+
+	buffer.WriteString(groupUsage(flagSet.groups, func(name string) string {
+		return "-" + name
+	}))
 
 	return buffer.String()
 }
@@ -4,6 +4,11 @@ import (
 	"bytes"
 	"flag"
 	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -547,3 +552,821 @@ func Test_DefaultFlagSet_PrintDefaults(t *testing.T) {
 	assert.Equal(t, "", original.String())
 	assert.Equal(t, "  -ok\n    \tis this ok\n", actual)
 }
+
+func Test_DefaultFlagSet_DefaultUsage_Groups(t *testing.T) {
+	flagSet := NewDefaultFlagSet()
+	flagSet.Bool("file", false, "write to file")
+	flagSet.Bool("stdout", false, "write to stdout")
+	flagSet.ExactlyOneOf("file", "stdout")
+
+	assert.Equal(t, "  -file\n    \twrite to file\n"+
+		"  -stdout\n    \twrite to stdout\n"+
+		"\n  exactly one of: -file, -stdout\n", flagSet.DefaultUsage())
+}
+
+func Test_DefaultFlagSet_VisitAll(t *testing.T) {
+	flagSet := NewDefaultFlagSet()
+	flagSet.String("string", "value", "")
+	flagSet.Bool("bool", true, "")
+	flagSet.StringArray("array", []string{"one", "two"}, "")
+
+	actual := map[string]string{}
+	order := []string{}
+	flagSet.VisitAll(func(name string, value Value) {
+		order = append(order, name)
+		actual[name] = value.String()
+	})
+
+	assert.Equal(t, []string{"array", "bool", "string"}, order)
+	assert.Equal(t, map[string]string{
+		"array":  "one,two",
+		"bool":   "true",
+		"string": "value",
+	}, actual)
+}
+
+func Test_DefaultFlagSet_Interspersed(t *testing.T) {
+
+	tests := []struct {
+		name          string
+		input         []string
+		expected      []string
+		expectedError error
+		values        map[string]interface{}
+	}{
+		{
+			name:     "flags after args",
+			input:    []string{"bob", "-admin", "-role", "owner", "other"},
+			expected: []string{"bob", "other"},
+			values: map[string]interface{}{
+				"admin": true,
+				"role":  "owner",
+			},
+		},
+		{
+			name:     "flags with equals",
+			input:    []string{"bob", "--role=owner", "-admin=false"},
+			expected: []string{"bob"},
+			values: map[string]interface{}{
+				"admin": false,
+				"role":  "owner",
+			},
+		},
+		{
+			name:     "terminator",
+			input:    []string{"bob", "-admin", "--", "-role", "owner"},
+			expected: []string{"bob", "-role", "owner"},
+			values: map[string]interface{}{
+				"admin": true,
+				"role":  "",
+			},
+		},
+		{
+			name:     "terminator as value",
+			input:    []string{"bob", "-role", "--", "-admin"},
+			expected: []string{"bob"},
+			values: map[string]interface{}{
+				"admin": true,
+				"role":  "--",
+			},
+		},
+		{
+			name:     "single dash",
+			input:    []string{"-", "-admin"},
+			expected: []string{"-"},
+			values: map[string]interface{}{
+				"admin": true,
+			},
+		},
+		{
+			name:          "unknown flag",
+			input:         []string{"bob", "-unknown", "value"},
+			expected:      []string{"bob", "value"},
+			expectedError: errors.FlagsetParseFailed("flag provided but not defined: -unknown"),
+		},
+		{
+			name:          "help",
+			input:         []string{"bob", "-h"},
+			expected:      []string{"bob"},
+			expectedError: errors.HelpRequested("flags"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			flagSet := NewDefaultFlagSet()
+			assert.False(t, flagSet.Interspersed())
+			flagSet.SetInterspersed(true)
+			assert.True(t, flagSet.Interspersed())
+
+			flagSet.Bool("admin", false, "")
+			flagSet.String("role", "", "")
+
+			actual, actualError := flagSet.Parse(test.input)
+			assert.Equal(t, test.expected, actual)
+			assert.Equal(t, test.expectedError, actualError)
+			for key, expected := range test.values {
+				assert.Equal(t, expected, flagSet.Get(key), key)
+			}
+		})
+	}
+
+	t.Run("SubFlagSet", func(t *testing.T) {
+		flagSet := NewDefaultFlagSet()
+		flagSet.SetInterspersed(true)
+		assert.True(t, flagSet.SubFlagSet("sub").Interspersed())
+	})
+}
+
+func Test_DefaultFlagSet_Validate(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		args     []string
+		set      map[string]string
+		expected string
+	}{
+		{
+			name:     "missing required",
+			args:     []string{},
+			expected: "flagset validation failed: -name is required",
+		},
+		{
+			name:     "valid",
+			args:     []string{"-name", "bob", "-count", "2"},
+			expected: "",
+		},
+		{
+			name:     "invalid values",
+			args:     []string{"-name", "Bob", "-count", "0"},
+			expected: "flagset validation failed: -count must be at least 1, -name must match \"^[a-z]+$\"",
+		},
+		{
+			name:     "set by parent",
+			args:     []string{},
+			set:      map[string]string{"name": "bob"},
+			expected: "",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			parent := NewDefaultFlagSet()
+			parent.String("name", "", "")
+			parent.Required("name")
+			parent.Validators("name", Pattern("^[a-z]+$"))
+			for name, value := range test.set {
+				assert.Nil(t, parent.Set(name, value))
+			}
+
+			flagSet := parent.SubFlagSet("sub")
+			flagSet.Int("count", 1, "")
+			flagSet.Validators("count", Min(1))
+
+			_, err := flagSet.Parse(test.args)
+			assert.Nil(t, err)
+
+			actual := flagSet.Validate()
+			if test.expected == "" {
+				assert.Nil(t, actual)
+			} else {
+				assert.True(t, errors.IsFlagsetValidationFailed(actual))
+				assert.EqualError(t, actual, test.expected)
+			}
+		})
+	}
+}
+
+func Test_DefaultFlagSet_Groups(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		args     []string
+		expected string
+	}{
+		{
+			name:     "valid",
+			args:     []string{"-file", "-user", "bob", "-password", "secret"},
+			expected: "",
+		},
+		{
+			name:     "invalid",
+			args:     []string{"-file", "-stdout", "-user", "bob"},
+			expected: "flagset validation failed: -password is required when -user is set, -stdout cannot be used with -file",
+		},
+		{
+			name:     "missing",
+			args:     []string{},
+			expected: "flagset validation failed: -file or -stdout is required",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			parent := NewDefaultFlagSet()
+			parent.Bool("file", false, "")
+			parent.Bool("stdout", false, "")
+			parent.ExactlyOneOf("file", "stdout")
+
+			flagSet := parent.SubFlagSet("sub")
+			flagSet.String("user", "", "")
+			flagSet.String("password", "", "")
+			flagSet.AllOrNone("user", "password")
+
+			_, err := flagSet.Parse(test.args)
+			assert.Nil(t, err)
+
+			actual := flagSet.Validate()
+			if test.expected == "" {
+				assert.Nil(t, actual)
+			} else {
+				assert.EqualError(t, actual, test.expected)
+			}
+			assert.Len(t, parent.groups, 1)
+		})
+	}
+}
+
+func Test_DefaultFlagSet_Env(t *testing.T) {
+	t.Setenv("TEST_HOST", "env-host")
+	t.Setenv("MYCLI_PORT", "8080")
+	t.Setenv("MYCLI_USER", "env-user")
+
+	parent := NewDefaultFlagSet()
+	parent.SetEnvPrefix("MYCLI_")
+	parent.String("host", "localhost", "the host")
+	parent.Env("host", "TEST_HOST")
+	parent.Int("port", 80, "the port")
+
+	flagSet := parent.SubFlagSet("sub")
+	flagSet.String("user", "", "the user")
+	flagSet.Bool("debug", false, "debug output")
+
+	_, err := flagSet.Parse([]string{"-user", "cli-user"})
+	assert.Nil(t, err)
+
+	host, _ := flagSet.GetString("host")
+	assert.Equal(t, "env-host", host)
+	assert.Equal(t, SourceEnvironment, flagSet.Source("host"))
+	port, _ := flagSet.GetInt("port")
+	assert.Equal(t, int64(8080), port)
+	assert.Equal(t, SourceEnvironment, parent.Source("port"))
+	user, _ := flagSet.GetString("user")
+	assert.Equal(t, "cli-user", user)
+	assert.Equal(t, SourceCommandLine, flagSet.Source("user"))
+	assert.Equal(t, SourceDefault, flagSet.Source("debug"))
+	assert.Equal(t, SourceDefault, flagSet.Source("undefined"))
+
+	assert.Equal(t, "  -debug\n"+
+		"    \tdebug output [$MYCLI_DEBUG]\n"+
+		"  -host string\n"+
+		"    \tthe host [$TEST_HOST, $MYCLI_HOST] (default \"localhost\")\n"+
+		"  -port int\n"+
+		"    \tthe port [$MYCLI_PORT] (default 80)\n"+
+		"  -user string\n"+
+		"    \tthe user [$MYCLI_USER]\n", flagSet.DefaultUsage())
+}
+
+func Test_DefaultFlagSet_Config(t *testing.T) {
+	t.Setenv("MYCLI_HOST", "env-host")
+
+	source := testConfig{
+		"host":           "config-host",
+		"role":           "viewer",
+		"users.role":     "editor",
+		"users.add.role": "admin",
+		"users.add.name": "config-name",
+	}
+
+	root := NewDefaultFlagSet()
+	root.SetEnvPrefix("MYCLI_")
+	root.String("host", "localhost", "")
+	root.String("role", "", "")
+	assert.Nil(t, root.SetConfig(source))
+	assert.Equal(t, source, root.Config())
+
+	role, _ := root.GetString("role")
+	assert.Equal(t, "viewer", role)
+
+	users := root.SubFlagSet("users")
+	_, err := users.Parse([]string{})
+	assert.Nil(t, err)
+	role, _ = users.GetString("role")
+	assert.Equal(t, "editor", role)
+
+	add := users.SubFlagSet("add")
+	add.String("name", "", "")
+	_, err = add.Parse([]string{"-name", "cli-name"})
+	assert.Nil(t, err)
+
+	role, _ = add.GetString("role")
+	assert.Equal(t, "admin", role)
+	assert.Equal(t, SourceConfig, add.Source("role"))
+	host, _ := add.GetString("host")
+	assert.Equal(t, "env-host", host)
+	assert.Equal(t, SourceEnvironment, add.Source("host"))
+	name, _ := add.GetString("name")
+	assert.Equal(t, "cli-name", name)
+	assert.Equal(t, SourceCommandLine, add.Source("name"))
+	assert.Equal(t, source, add.Config())
+
+	t.Run("invalid", func(t *testing.T) {
+		flagSet := NewDefaultFlagSet()
+		flagSet.Int("count", 0, "")
+		err := flagSet.SetConfig(testConfig{"count": "abc"})
+		assert.EqualError(t, err, "flagset parse failed invalid value \"abc\" for flag -count from config count: parse error")
+	})
+}
+
+func Test_DefaultFlagSet_Types(t *testing.T) {
+	flagSet := &DefaultFlagSet{}
+	flagSet.IntSlice("ints", []int64{1}, "")
+	flagSet.UintSlice("uints", nil, "")
+	flagSet.FloatSlice("floats", nil, "")
+	flagSet.StringMap("labels", nil, "")
+	flagSet.Time("since", time.Time{}, "2006-01-02", "")
+	flagSet.IP("ip", nil, "")
+	flagSet.IPNet("network", net.IPNet{}, "")
+	flagSet.URL("url", nil, "")
+	flagSet.ByteSize("size", 1024, "")
+	flagSet.Regexp("pattern", nil, "")
+	flagSet.Path("dir", ".", PathDir, "")
+
+	_, err := flagSet.Parse([]string{
+		"-ints", "2,3",
+		"-uints", "4",
+		"-floats", "1.5",
+		"-labels", "env=prod",
+		"-since", "2021-03-04",
+		"-ip", "10.0.0.1",
+		"-network", "10.0.0.0/8",
+		"-url", "https://example.com",
+		"-size", "2MiB",
+		"-pattern", "^a+$",
+	})
+	assert.Nil(t, err)
+
+	ints, ok := flagSet.GetIntSlice("ints")
+	assert.True(t, ok)
+	assert.Equal(t, []int64{2, 3}, ints)
+
+	uints, ok := flagSet.GetUintSlice("uints")
+	assert.True(t, ok)
+	assert.Equal(t, []uint64{4}, uints)
+
+	floats, ok := flagSet.GetFloatSlice("floats")
+	assert.True(t, ok)
+	assert.Equal(t, []float64{1.5}, floats)
+
+	labels, ok := flagSet.GetStringMap("labels")
+	assert.True(t, ok)
+	assert.Equal(t, map[string]string{"env": "prod"}, labels)
+
+	since, ok := flagSet.GetTime("since")
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2021, 3, 4, 0, 0, 0, 0, time.UTC), since)
+
+	ip, ok := flagSet.GetIP("ip")
+	assert.True(t, ok)
+	assert.Equal(t, "10.0.0.1", ip.String())
+
+	network, ok := flagSet.GetIPNet("network")
+	assert.True(t, ok)
+	assert.Equal(t, "10.0.0.0/8", network.String())
+
+	address, ok := flagSet.GetURL("url")
+	assert.True(t, ok)
+	assert.Equal(t, "example.com", address.Host)
+
+	size, ok := flagSet.GetUint("size")
+	assert.True(t, ok)
+	assert.Equal(t, uint64(2<<20), size)
+
+	pattern, ok := flagSet.GetRegexp("pattern")
+	assert.True(t, ok)
+	assert.True(t, pattern.MatchString("aaa"))
+
+	dir, ok := flagSet.GetString("dir")
+	assert.True(t, ok)
+	assert.Equal(t, ".", dir)
+
+	assert.NotNil(t, flagSet.Set("dir", "missing-directory"))
+
+	_, ok = flagSet.GetIntSlice("uints")
+	assert.False(t, ok)
+}
+
+func Test_DefaultFlagSet_Enum(t *testing.T) {
+	flagSet := NewDefaultFlagSet()
+	flagSet.Enum("output", "table", []string{"json", "yaml", "table"}, "the output `format`")
+	flagSet.Env("output", "OUTPUT")
+
+	assert.Equal(t, "  -output format\n"+
+		"    \tthe output format (one of: json, yaml, table) [$OUTPUT] (default table)\n", flagSet.DefaultUsage())
+
+	_, err := flagSet.Parse([]string{"-output", "xml"})
+	assert.EqualError(t, err, "flagset parse failed invalid value \"xml\" for flag -output: must be one of: json, yaml, table")
+
+	_, err = flagSet.Parse([]string{"-output", "json"})
+	assert.Nil(t, err)
+	value, ok := flagSet.GetString("output")
+	assert.True(t, ok)
+	assert.Equal(t, "json", value)
+
+	flagSet.VisitAll(func(name string, value Value) {
+		choiceValue, ok := value.(ChoiceValue)
+		assert.True(t, ok)
+		assert.Equal(t, []string{"json", "yaml", "table"}, choiceValue.Choices())
+	})
+}
+
+func Test_DefaultFlagSet_Count(t *testing.T) {
+	flagSet := NewDefaultFlagSet()
+	flagSet.Count("v", "verbosity")
+
+	assert.Equal(t, "  -v\tverbosity\n", flagSet.DefaultUsage())
+
+	_, err := flagSet.Parse([]string{"-v", "-v", "-v"})
+	assert.Nil(t, err)
+	value, ok := flagSet.GetInt("v")
+	assert.True(t, ok)
+	assert.Equal(t, int64(3), value)
+
+	subFlagSet := flagSet.SubFlagSet("sub")
+	_, err = subFlagSet.Parse([]string{"-v"})
+	assert.Nil(t, err)
+	value, _ = subFlagSet.GetInt("v")
+	assert.Equal(t, int64(4), value)
+	assert.Equal(t, SourceCommandLine, subFlagSet.Source("v"))
+}
+
+func Test_DefaultFlagSet_Count_Bundle(t *testing.T) {
+	newFlagSet := func() *DefaultFlagSet {
+		flagSet := NewDefaultFlagSet()
+		flagSet.Count("v", "verbosity")
+		flagSet.Count("q", "quietness")
+		flagSet.Bool("b", false, "a bool")
+		flagSet.Bool("vv", false, "a flag named like a bundle")
+		return flagSet
+	}
+
+	t.Run("bundle", func(t *testing.T) {
+		flagSet := newFlagSet()
+		args, err := flagSet.Parse([]string{"-vvv", "-qvq", "arg"})
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"arg"}, args)
+		verbosity, _ := flagSet.GetInt("v")
+		assert.Equal(t, int64(4), verbosity)
+		quietness, _ := flagSet.GetInt("q")
+		assert.Equal(t, int64(2), quietness)
+	})
+
+	t.Run("interspersed", func(t *testing.T) {
+		flagSet := newFlagSet()
+		flagSet.SetInterspersed(true)
+		args, err := flagSet.Parse([]string{"arg", "-vvv"})
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"arg"}, args)
+		verbosity, _ := flagSet.GetInt("v")
+		assert.Equal(t, int64(3), verbosity)
+	})
+
+	t.Run("defined name", func(t *testing.T) {
+		flagSet := newFlagSet()
+		_, err := flagSet.Parse([]string{"-vv"})
+		assert.Nil(t, err)
+		verbosity, _ := flagSet.GetInt("v")
+		assert.Equal(t, int64(0), verbosity)
+		bundle, _ := flagSet.GetBool("vv")
+		assert.True(t, bundle)
+	})
+
+	t.Run("not a count flag", func(t *testing.T) {
+		flagSet := newFlagSet()
+		_, err := flagSet.Parse([]string{"-vb"})
+		assert.EqualError(t, err, "flagset parse failed flag provided but not defined: -vb")
+	})
+}
+
+func Test_DefaultFlagSet_Negatable(t *testing.T) {
+	flagSet := NewDefaultFlagSet()
+	flagSet.Bool("color", true, "colored output")
+	flagSet.Negatable("color")
+	flagSet.Bool("debug", false, "debug output")
+	flagSet.String("name", "", "the name")
+
+	assert.Equal(t, "  -color\n"+
+		"    \tcolored output (negate with -no-color) (default true)\n"+
+		"  -debug\n"+
+		"    \tdebug output\n"+
+		"  -name string\n"+
+		"    \tthe name\n", flagSet.DefaultUsage())
+
+	t.Run("negated", func(t *testing.T) {
+		subFlagSet := flagSet.SubFlagSet("sub")
+		args, err := subFlagSet.Parse([]string{"--no-color", "arg"})
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"arg"}, args)
+		value, _ := subFlagSet.GetBool("color")
+		assert.False(t, value)
+		assert.Equal(t, SourceCommandLine, subFlagSet.Source("color"))
+		assert.Nil(t, subFlagSet.Set("color", "true"))
+	})
+
+	t.Run("not negatable", func(t *testing.T) {
+		subFlagSet := flagSet.SubFlagSet("sub")
+		_, err := subFlagSet.Parse([]string{"-no-debug"})
+		assert.EqualError(t, err, "flagset parse failed flag provided but not defined: -no-debug")
+	})
+
+	t.Run("flag value", func(t *testing.T) {
+		subFlagSet := flagSet.SubFlagSet("sub")
+		args, err := subFlagSet.Parse([]string{"-name", "-no-color"})
+		assert.Nil(t, err)
+		assert.Empty(t, args)
+		value, _ := subFlagSet.GetString("name")
+		assert.Equal(t, "-no-color", value)
+	})
+
+	t.Run("interspersed", func(t *testing.T) {
+		subFlagSet := flagSet.SubFlagSet("sub")
+		subFlagSet.SetInterspersed(true)
+		args, err := subFlagSet.Parse([]string{"arg", "-no-color", "--", "-no-color"})
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"arg", "-no-color"}, args)
+		value, _ := subFlagSet.GetBool("color")
+		assert.False(t, value)
+	})
+
+	t.Run("terminated", func(t *testing.T) {
+		subFlagSet := flagSet.SubFlagSet("sub")
+		assert.Nil(t, subFlagSet.Set("color", "true"))
+		args, err := subFlagSet.Parse([]string{"arg", "-no-color"})
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"arg", "-no-color"}, args)
+		value, _ := subFlagSet.GetBool("color")
+		assert.True(t, value)
+	})
+}
+
+func Test_DefaultFlagSet_Changed(t *testing.T) {
+	flagSet := NewDefaultFlagSet()
+	flagSet.String("name", "default", "")
+	flagSet.Bool("debug", false, "")
+
+	assert.False(t, flagSet.Changed("name"))
+	assert.False(t, flagSet.Changed("missing"))
+
+	_, err := flagSet.Parse([]string{"-name", "default"})
+	assert.Nil(t, err)
+	assert.True(t, flagSet.Changed("name"))
+	assert.False(t, flagSet.Changed("debug"))
+
+	subFlagSet := flagSet.SubFlagSet("sub")
+	assert.True(t, subFlagSet.Changed("name"))
+	assert.False(t, subFlagSet.Changed("debug"))
+
+	assert.Nil(t, subFlagSet.Set("debug", "false"))
+	assert.True(t, subFlagSet.Changed("debug"))
+}
+
+func Test_DefaultFlagSet_Local(t *testing.T) {
+	flagSet := NewDefaultFlagSet()
+	flagSet.String("name", "", "")
+	flagSet.String("id", "", "")
+	flagSet.Bool("json", false, "")
+	flagSet.Bool("yaml", false, "")
+	flagSet.Local("id")
+	flagSet.Local("json")
+	flagSet.Required("id")
+	flagSet.AtMostOneOf("json", "yaml")
+
+	_, err := flagSet.Parse([]string{"-name", "bob", "-json"})
+	assert.Nil(t, err)
+
+	subFlagSet := flagSet.SubFlagSet("sub")
+	names := []string{}
+	subFlagSet.VisitAll(func(name string, value Value) {
+		names = append(names, name)
+	})
+	assert.Equal(t, []string{"name", "yaml"}, names)
+	assert.Nil(t, subFlagSet.Get("id"))
+	assert.False(t, subFlagSet.Changed("json"))
+	assert.True(t, subFlagSet.Changed("name"))
+
+	_, err = subFlagSet.Parse([]string{"-id", "1"})
+	assert.EqualError(t, err, "flagset parse failed flag provided but not defined: -id")
+
+	_, err = subFlagSet.Parse([]string{"-yaml"})
+	assert.Nil(t, err)
+	assert.EqualError(t, subFlagSet.Validate(), "flagset validation failed: -id is required, -yaml cannot be used with -json")
+
+	assert.Nil(t, flagSet.Set("id", "1"))
+	assert.EqualError(t, subFlagSet.SubFlagSet("leaf").Validate(), "flagset validation failed: -yaml cannot be used with -json")
+}
+
+func Test_DefaultFlagSet_Snapshot(t *testing.T) {
+	flagSet := NewDefaultFlagSet()
+	flagSet.String("name", "default", "")
+	flagSet.Reset()
+
+	flagSet.Snapshot()
+	tags := &StringArrayFlag{}
+	for i := 0; i < 2; i++ {
+		subFlagSet := flagSet.SubFlagSet("sub")
+		subFlagSet.Var(tags, "tag", "")
+		_, err := subFlagSet.Parse([]string{"-name", "bob", "-tag", "a"})
+		assert.Nil(t, err)
+
+		name, _ := subFlagSet.GetString("name")
+		assert.Equal(t, "bob", name)
+		assert.Equal(t, &StringArrayFlag{"a"}, tags)
+
+		flagSet.Reset()
+		name, _ = flagSet.GetString("name")
+		assert.Equal(t, "default", name)
+		assert.False(t, flagSet.Changed("name"))
+		assert.Equal(t, &StringArrayFlag{}, tags)
+	}
+}
+
+func Test_DefaultFlagSet_Snapshot_Values(t *testing.T) {
+	flagSet := NewDefaultFlagSet()
+	flagSet.StringMap("labels", map[string]string{"env": "dev"}, "")
+	flagSet.URL("endpoint", &url.URL{Scheme: "https", Host: "example.com"}, "")
+	flagSet.Snapshot()
+
+	_, err := flagSet.Parse([]string{"-labels", "env=prod,tier=web"})
+	assert.Nil(t, err)
+	endpoint, _ := flagSet.GetURL("endpoint")
+	endpoint.Host = "modified.com"
+
+	flagSet.Reset()
+	labels, _ := flagSet.GetStringMap("labels")
+	assert.Equal(t, map[string]string{"env": "dev"}, labels)
+	endpoint, _ = flagSet.GetURL("endpoint")
+	assert.Equal(t, "https://example.com", endpoint.String())
+
+	_, err = flagSet.Parse([]string{"-labels", "tier=web"})
+	assert.Nil(t, err)
+	labels, _ = flagSet.GetStringMap("labels")
+	assert.Equal(t, map[string]string{"tier": "web"}, labels)
+
+	t.Run("custom value", func(t *testing.T) {
+		target := "one"
+		flagSet := NewDefaultFlagSet()
+		flagSet.Var(&pointerValue{P: &target}, "x", "")
+		flagSet.Snapshot()
+
+		_, err := flagSet.Parse([]string{"-x", "two"})
+		assert.Nil(t, err)
+		flagSet.Reset()
+		assert.Equal(t, "one", target)
+
+		_, err = flagSet.Parse([]string{"-x", "two"})
+		assert.Nil(t, err)
+		assert.Equal(t, "two", target)
+	})
+}
+
+func Test_DefaultFlagSet_Alias(t *testing.T) {
+	flagSet := NewDefaultFlagSet()
+	flagSet.String("output", "out.txt", "the output `file`")
+	flagSet.Alias("output", "o")
+	flagSet.DeprecatedAlias("output", "outfile", "use -output instead")
+	flagSet.Bool("verbose", false, "verbose output")
+
+	assert.Equal(t, "  -output, -o, -outfile file\n    \tthe output file (default \"out.txt\")\n  -verbose\n    \tverbose output\n", flagSet.DefaultUsage())
+
+	names := []string{}
+	flagSet.VisitAll(func(name string, value Value) {
+		names = append(names, name)
+	})
+	assert.Equal(t, []string{"output", "verbose"}, names)
+
+	_, err := flagSet.Parse([]string{"-o", "a.txt"})
+	assert.Nil(t, err)
+	assert.Equal(t, "a.txt", flagSet.Get("output"))
+	assert.Equal(t, "a.txt", flagSet.Get("outfile"))
+	assert.True(t, flagSet.Changed("output"))
+	assert.True(t, flagSet.Changed("outfile"))
+	assert.Empty(t, flagSet.Warnings())
+
+	subFlagSet := flagSet.SubFlagSet("sub")
+	_, err = subFlagSet.Parse([]string{"-outfile", "b.txt"})
+	assert.Nil(t, err)
+	assert.Equal(t, "b.txt", subFlagSet.Get("o"))
+	assert.Equal(t, []string{"Flag \"-outfile\" is deprecated, use -output instead"}, subFlagSet.Warnings())
+
+	assert.PanicsWithValue(t, "flag \"missing\" is not defined", func() {
+		flagSet.Alias("missing", "m")
+	})
+}
+
+func Test_DefaultFlagSet_HiddenAndDeprecated(t *testing.T) {
+	flagSet := NewDefaultFlagSet()
+	flagSet.Bool("debug-trace", false, "trace output")
+	flagSet.Hidden("debug-trace")
+	flagSet.String("user", "", "the user name")
+	flagSet.Alias("user", "u")
+	flagSet.Deprecated("user", "use -name instead")
+	flagSet.String("name", "", "the name")
+
+	assert.Equal(t, "  -name string\n    \tthe name\n  -user, -u string\n    \tthe user name\n", flagSet.DefaultUsage())
+
+	_, err := flagSet.Parse([]string{"-debug-trace", "-name", "bob"})
+	assert.Nil(t, err)
+	assert.Equal(t, true, flagSet.Get("debug-trace"))
+	assert.Empty(t, flagSet.Warnings())
+
+	subFlagSet := flagSet.SubFlagSet("sub")
+	_, err = subFlagSet.Parse([]string{"-u", "bob", "-user", "bob"})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{
+		"Flag \"-u\" is deprecated, use -name instead",
+		"Flag \"-user\" is deprecated, use -name instead",
+	}, subFlagSet.Warnings())
+}
+
+func Test_DefaultFlagSet_Describe(t *testing.T) {
+	flagSet := NewDefaultFlagSet()
+	flagSet.SetEnvPrefix("CLI_")
+	flagSet.Bool("verbose", false, "verbose output")
+	flagSet.Alias("verbose", "v")
+	flagSet.Bool("trace", false, "trace output")
+	flagSet.Hidden("trace")
+
+	subFlagSet := flagSet.SubFlagSet("users")
+	subFlagSet.Enum("role", "viewer", []string{"viewer", "admin"}, "the user role")
+	subFlagSet.Required("role")
+	subFlagSet.Local("role")
+
+	descriptors := subFlagSet.Describe()
+	assert.Len(t, descriptors, 3)
+	assert.Equal(t, FlagDescriptor{
+		Name:     "role",
+		Type:     "string",
+		Default:  "viewer",
+		Usage:    "the user role",
+		EnvVars:  []string{"CLI_ROLE"},
+		Choices:  []string{"viewer", "admin"},
+		Required: true,
+		Local:    true,
+		Aliases:  []string{},
+		Path:     []string{"users"},
+		Value:    descriptors[0].Value,
+	}, descriptors[0])
+	assert.Equal(t, "trace", descriptors[1].Name)
+	assert.True(t, descriptors[1].Hidden)
+	assert.Equal(t, "verbose", descriptors[2].Name)
+	assert.Equal(t, []string{"v"}, descriptors[2].Aliases)
+	assert.Equal(t, "bool", descriptors[2].Type)
+	assert.Equal(t, "false", descriptors[2].Default)
+	assert.Empty(t, descriptors[2].Path)
+}
+
+func Test_DefaultFlagSet_FileValue(t *testing.T) {
+	passwordPath := filepath.Join(t.TempDir(), "password")
+	assert.Nil(t, os.WriteFile(passwordPath, []byte("s3cret\n"), 0600))
+
+	flagSet := NewDefaultFlagSet()
+	flagSet.String("password", "", "")
+	flagSet.FileValue("password")
+	flagSet.String("body", "", "")
+	flagSet.String("name", "", "")
+	flagSet.SetInput(strings.NewReader("{}\n"))
+
+	subFlagSet := flagSet.SubFlagSet("sub")
+	subFlagSet.FileValue("body")
+	_, err := subFlagSet.Parse([]string{"-password", "@" + passwordPath, "-body=@-", "-name", "@bob"})
+	assert.Nil(t, err)
+	password, _ := subFlagSet.GetString("password")
+	assert.Equal(t, "s3cret", password)
+	body, _ := subFlagSet.GetString("body")
+	assert.Equal(t, "{}", body)
+	name, _ := subFlagSet.GetString("name")
+	assert.Equal(t, "@bob", name)
+
+	flagSet.SetFileValues(true)
+	flagSet.SetFileLimit(2)
+	_, err = flagSet.SubFlagSet("sub").Parse([]string{"-name", "@" + passwordPath})
+	assert.EqualError(t, err, "flagset parse failed value for flag -name exceeds the limit of 2 bytes")
+}
+
+func Test_DefaultFlagSet_Secret(t *testing.T) {
+	flagSet := NewDefaultFlagSet()
+	flagSet.Secret("password", "the account password")
+	flagSet.Env("password", "TEST_SECRET_PASSWORD")
+	t.Setenv("TEST_SECRET_PASSWORD", "s3cret")
+
+	_, err := flagSet.Parse([]string{})
+	assert.Nil(t, err)
+	password, _ := flagSet.GetString("password")
+	assert.Equal(t, "s3cret", password)
+	assert.Equal(t, "  -password value\n    \tthe account password [$TEST_SECRET_PASSWORD]\n", flagSet.DefaultUsage())
+
+	descriptors := flagSet.Describe()
+	assert.True(t, descriptors[0].Secret)
+	assert.Equal(t, "secret", descriptors[0].Type)
+	assert.Equal(t, RedactedValue, descriptors[0].Value.String())
+}
@@ -0,0 +1,136 @@
+package flags
+
+import (
+	"net"
+	"net/url"
+	"regexp"
+	"time"
+)
+
+// getBool returns the flag value as a bool.
+func getBool(value interface{}) (bool, bool) {
+	if boolValue, ok := value.(bool); ok {
+		return boolValue, true
+	}
+	return false, false
+}
+
+// getInt returns the flag value as a int64.
+func getInt(value interface{}) (int64, bool) {
+	if intValue, ok := value.(int64); ok {
+		return intValue, true
+	}
+	return 0, false
+}
+
+// getUint returns the flag value as a uint64.
+func getUint(value interface{}) (uint64, bool) {
+	if intValue, ok := value.(uint64); ok {
+		return intValue, true
+	}
+	return 0, false
+}
+
+// getString returns the flag value as a string.
+func getString(value interface{}) (string, bool) {
+	if stringValue, ok := value.(string); ok {
+		return stringValue, true
+	}
+	return "", false
+}
+
+// getStringArray returns the flag value as a string array.
+func getStringArray(value interface{}) ([]string, bool) {
+	if arrayValue, ok := value.([]string); ok {
+		return arrayValue, true
+	}
+	return nil, false
+}
+
+// getFloat returns the flag value as a float64.
+func getFloat(value interface{}) (float64, bool) {
+	if floatValue, ok := value.(float64); ok {
+		return floatValue, true
+	}
+	return 0, false
+}
+
+// getDuration returns the flag value as a time.Duration.
+func getDuration(value interface{}) (time.Duration, bool) {
+	if durationValue, ok := value.(time.Duration); ok {
+		return durationValue, true
+	}
+	return time.Duration(0), false
+}
+
+// getIntSlice returns the flag value as a int64 slice.
+func getIntSlice(value interface{}) ([]int64, bool) {
+	if typedValue, ok := value.([]int64); ok {
+		return typedValue, true
+	}
+	return nil, false
+}
+
+// getUintSlice returns the flag value as a uint64 slice.
+func getUintSlice(value interface{}) ([]uint64, bool) {
+	if typedValue, ok := value.([]uint64); ok {
+		return typedValue, true
+	}
+	return nil, false
+}
+
+// getFloatSlice returns the flag value as a float64 slice.
+func getFloatSlice(value interface{}) ([]float64, bool) {
+	if typedValue, ok := value.([]float64); ok {
+		return typedValue, true
+	}
+	return nil, false
+}
+
+// getStringMap returns the flag value as a map[string]string.
+func getStringMap(value interface{}) (map[string]string, bool) {
+	if typedValue, ok := value.(map[string]string); ok {
+		return typedValue, true
+	}
+	return nil, false
+}
+
+// getTime returns the flag value as a time.Time.
+func getTime(value interface{}) (time.Time, bool) {
+	if typedValue, ok := value.(time.Time); ok {
+		return typedValue, true
+	}
+	return time.Time{}, false
+}
+
+// getIP returns the flag value as a net.IP.
+func getIP(value interface{}) (net.IP, bool) {
+	if typedValue, ok := value.(net.IP); ok {
+		return typedValue, true
+	}
+	return nil, false
+}
+
+// getIPNet returns the flag value as a net.IPNet.
+func getIPNet(value interface{}) (net.IPNet, bool) {
+	if typedValue, ok := value.(net.IPNet); ok {
+		return typedValue, true
+	}
+	return net.IPNet{}, false
+}
+
+// getURL returns the flag value as a *url.URL.
+func getURL(value interface{}) (*url.URL, bool) {
+	if typedValue, ok := value.(*url.URL); ok {
+		return typedValue, true
+	}
+	return nil, false
+}
+
+// getRegexp returns the flag value as a *regexp.Regexp.
+func getRegexp(value interface{}) (*regexp.Regexp, bool) {
+	if typedValue, ok := value.(*regexp.Regexp); ok {
+		return typedValue, true
+	}
+	return nil, false
+}
@@ -0,0 +1,178 @@
+package flags
+
+import (
+	"net"
+	"net/url"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Getters(t *testing.T) {
+
+	t.Run("getBool", func(t *testing.T) {
+		actual, ok := getBool(true)
+		assert.True(t, ok)
+		assert.True(t, actual)
+
+		actual, ok = getBool("true")
+		assert.False(t, ok)
+		assert.False(t, actual)
+	})
+
+	t.Run("getInt", func(t *testing.T) {
+		actual, ok := getInt(int64(1))
+		assert.True(t, ok)
+		assert.Equal(t, int64(1), actual)
+
+		actual, ok = getInt(1)
+		assert.False(t, ok)
+		assert.Equal(t, int64(0), actual)
+	})
+
+	t.Run("getUint", func(t *testing.T) {
+		actual, ok := getUint(uint64(1))
+		assert.True(t, ok)
+		assert.Equal(t, uint64(1), actual)
+
+		actual, ok = getUint(nil)
+		assert.False(t, ok)
+		assert.Equal(t, uint64(0), actual)
+	})
+
+	t.Run("getString", func(t *testing.T) {
+		actual, ok := getString("value")
+		assert.True(t, ok)
+		assert.Equal(t, "value", actual)
+
+		actual, ok = getString(nil)
+		assert.False(t, ok)
+		assert.Equal(t, "", actual)
+	})
+
+	t.Run("getStringArray", func(t *testing.T) {
+		actual, ok := getStringArray([]string{"value"})
+		assert.True(t, ok)
+		assert.Equal(t, []string{"value"}, actual)
+
+		actual, ok = getStringArray("value")
+		assert.False(t, ok)
+		assert.Nil(t, actual)
+	})
+
+	t.Run("getFloat", func(t *testing.T) {
+		actual, ok := getFloat(1.5)
+		assert.True(t, ok)
+		assert.Equal(t, 1.5, actual)
+
+		actual, ok = getFloat(nil)
+		assert.False(t, ok)
+		assert.Equal(t, float64(0), actual)
+	})
+
+	t.Run("getDuration", func(t *testing.T) {
+		actual, ok := getDuration(time.Second)
+		assert.True(t, ok)
+		assert.Equal(t, time.Second, actual)
+
+		actual, ok = getDuration(int64(1))
+		assert.False(t, ok)
+		assert.Equal(t, time.Duration(0), actual)
+	})
+
+	t.Run("getIntSlice", func(t *testing.T) {
+		actual, ok := getIntSlice([]int64{1, 2})
+		assert.True(t, ok)
+		assert.Equal(t, []int64{1, 2}, actual)
+
+		actual, ok = getIntSlice([]uint64{1})
+		assert.False(t, ok)
+		assert.Nil(t, actual)
+	})
+
+	t.Run("getUintSlice", func(t *testing.T) {
+		actual, ok := getUintSlice([]uint64{1, 2})
+		assert.True(t, ok)
+		assert.Equal(t, []uint64{1, 2}, actual)
+
+		actual, ok = getUintSlice([]int64{1})
+		assert.False(t, ok)
+		assert.Nil(t, actual)
+	})
+
+	t.Run("getFloatSlice", func(t *testing.T) {
+		actual, ok := getFloatSlice([]float64{1.5})
+		assert.True(t, ok)
+		assert.Equal(t, []float64{1.5}, actual)
+
+		actual, ok = getFloatSlice(nil)
+		assert.False(t, ok)
+		assert.Nil(t, actual)
+	})
+
+	t.Run("getStringMap", func(t *testing.T) {
+		actual, ok := getStringMap(map[string]string{"a": "1"})
+		assert.True(t, ok)
+		assert.Equal(t, map[string]string{"a": "1"}, actual)
+
+		actual, ok = getStringMap("a=1")
+		assert.False(t, ok)
+		assert.Nil(t, actual)
+	})
+
+	t.Run("getTime", func(t *testing.T) {
+		now := time.Now()
+		actual, ok := getTime(now)
+		assert.True(t, ok)
+		assert.Equal(t, now, actual)
+
+		actual, ok = getTime(time.Second)
+		assert.False(t, ok)
+		assert.Equal(t, time.Time{}, actual)
+	})
+
+	t.Run("getIP", func(t *testing.T) {
+		actual, ok := getIP(net.ParseIP("10.0.0.1"))
+		assert.True(t, ok)
+		assert.Equal(t, net.ParseIP("10.0.0.1"), actual)
+
+		actual, ok = getIP("10.0.0.1")
+		assert.False(t, ok)
+		assert.Nil(t, actual)
+	})
+
+	t.Run("getIPNet", func(t *testing.T) {
+		_, ipNet, _ := net.ParseCIDR("10.0.0.0/8")
+		actual, ok := getIPNet(*ipNet)
+		assert.True(t, ok)
+		assert.Equal(t, *ipNet, actual)
+
+		actual, ok = getIPNet(ipNet)
+		assert.False(t, ok)
+		assert.Equal(t, net.IPNet{}, actual)
+	})
+
+	t.Run("getURL", func(t *testing.T) {
+		expected, _ := url.Parse("https://example.com")
+		actual, ok := getURL(expected)
+		assert.True(t, ok)
+		assert.Equal(t, expected, actual)
+
+		actual, ok = getURL("https://example.com")
+		assert.False(t, ok)
+		assert.Nil(t, actual)
+	})
+
+	t.Run("getRegexp", func(t *testing.T) {
+		expected := regexp.MustCompile("^a")
+		actual, ok := getRegexp(expected)
+		assert.True(t, ok)
+		assert.Equal(t, expected, actual)
+
+		actual, ok = getRegexp("^a")
+		assert.False(t, ok)
+		assert.Nil(t, actual)
+	})
+}
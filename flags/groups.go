@@ -0,0 +1,124 @@
+package flags
+
+import (
+	"fmt"
+	"strings"
+)
+
+// groupKind describes the relationship between the flags in a flag group.
+type groupKind int
+
+const (
+	// exactlyOneOf requires one, and only one, of the flags to be set.
+	exactlyOneOf groupKind = iota
+	// atMostOneOf allows no more than one of the flags to be set.
+	atMostOneOf
+	// allOrNone requires all of the flags to be set if any of them are set.
+	allOrNone
+)
+
+// String returns the description of the relationship.
+func (kind groupKind) String() string {
+	switch kind {
+	case exactlyOneOf:
+		return "exactly one of"
+	case atMostOneOf:
+		return "at most one of"
+	case allOrNone:
+		return "all or none of"
+	}
+	return ""
+}
+
+// flagGroup defines a relationship between a group of flags.
+type flagGroup struct {
+	kind  groupKind
+	names []string
+}
+
+// newFlagGroup returns a new flagGroup, and will panic if the group contains fewer than two flags.
+func newFlagGroup(kind groupKind, names []string) *flagGroup {
+	if len(names) < 2 {
+		panic(fmt.Sprintf("flag group %q requires at least two flags", kind.String()))
+	}
+	return &flagGroup{
+		kind:  kind,
+		names: append([]string{}, names...),
+	}
+}
+
+// hasLocal returns true if any of the flags in the group are local flags.
+func (group *flagGroup) hasLocal(metadata map[string]*flagMeta) bool {
+	for _, name := range group.names {
+		if meta, ok := metadata[name]; ok && meta.local {
+			return true
+		}
+	}
+	return false
+}
+
+// validate adds a validation failure for any flag that breaks the group relationship,
+// using flagName to format each of the flag names in the failures.
+func (group *flagGroup) validate(isChanged func(name string) bool, flagName func(name string) string, failures map[string]error) {
+	fail := func(name string, err error) {
+		if _, exists := failures[name]; !exists {
+			failures[name] = err
+		}
+	}
+
+	set := make([]string, 0, len(group.names))
+	unset := make([]string, 0, len(group.names))
+	for _, name := range group.names {
+		if isChanged(name) {
+			set = append(set, name)
+		} else {
+			unset = append(unset, name)
+		}
+	}
+
+	switch group.kind {
+	case exactlyOneOf, atMostOneOf:
+		if len(set) == 0 && group.kind == exactlyOneOf {
+			fail(group.names[0], fmt.Errorf("or %s is required", joinFlagNames(group.names[1:], " or ", flagName)))
+		}
+		for i := 1; i < len(set); i++ {
+			fail(set[i], fmt.Errorf("cannot be used with %s", flagName(set[0])))
+		}
+	case allOrNone:
+		if len(set) > 0 {
+			for _, name := range unset {
+				fail(name, fmt.Errorf("is required when %s is set", flagName(set[0])))
+			}
+		}
+	}
+}
+
+// usage returns a description of the flag group, using flagName to format each of the flag names.
+func (group *flagGroup) usage(flagName func(name string) string) string {
+	names := make([]string, len(group.names))
+	for i, name := range group.names {
+		names[i] = flagName(name)
+	}
+	return fmt.Sprintf("%s: %s", group.kind.String(), strings.Join(names, ", "))
+}
+
+// groupUsage returns the usage for the flag groups, or an empty string if there are no groups.
+func groupUsage(groups []*flagGroup, flagName func(name string) string) string {
+	if len(groups) == 0 {
+		return ""
+	}
+	lines := make([]string, len(groups))
+	for i, group := range groups {
+		lines[i] = fmt.Sprintf("  %s\n", group.usage(flagName))
+	}
+	return "\n" + strings.Join(lines, "")
+}
+
+// joinFlagNames joins the flag names using the specified separator, using flagName to format each of the flag names.
+func joinFlagNames(names []string, separator string, flagName func(name string) string) string {
+	formatted := make([]string, len(names))
+	for i, name := range names {
+		formatted[i] = flagName(name)
+	}
+	return strings.Join(formatted, separator)
+}
@@ -0,0 +1,141 @@
+package flags
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_newFlagGroup(t *testing.T) {
+	names := []string{"file", "stdout"}
+	actual := newFlagGroup(exactlyOneOf, names)
+	assert.Equal(t, &flagGroup{kind: exactlyOneOf, names: names}, actual)
+
+	t.Run("panics", func(t *testing.T) {
+		testPanic(t, func() {
+			newFlagGroup(atMostOneOf, []string{"file"})
+		}, "flag group \"at most one of\" requires at least two flags")
+	})
+}
+
+func Test_flagGroup_validate(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		kind     groupKind
+		set      []string
+		expected map[string]string
+	}{
+		{
+			name:     "exactly one of none set",
+			kind:     exactlyOneOf,
+			set:      []string{},
+			expected: map[string]string{"a": "or -b or -c is required"},
+		},
+		{
+			name:     "exactly one of one set",
+			kind:     exactlyOneOf,
+			set:      []string{"b"},
+			expected: map[string]string{},
+		},
+		{
+			name: "exactly one of multiple set",
+			kind: exactlyOneOf,
+			set:  []string{"a", "b", "c"},
+			expected: map[string]string{
+				"b": "cannot be used with -a",
+				"c": "cannot be used with -a",
+			},
+		},
+		{
+			name:     "at most one of none set",
+			kind:     atMostOneOf,
+			set:      []string{},
+			expected: map[string]string{},
+		},
+		{
+			name:     "at most one of multiple set",
+			kind:     atMostOneOf,
+			set:      []string{"b", "c"},
+			expected: map[string]string{"c": "cannot be used with -b"},
+		},
+		{
+			name:     "all or none none set",
+			kind:     allOrNone,
+			set:      []string{},
+			expected: map[string]string{},
+		},
+		{
+			name:     "all or none all set",
+			kind:     allOrNone,
+			set:      []string{"a", "b", "c"},
+			expected: map[string]string{},
+		},
+		{
+			name: "all or none some set",
+			kind: allOrNone,
+			set:  []string{"b"},
+			expected: map[string]string{
+				"a": "is required when -b is set",
+				"c": "is required when -b is set",
+			},
+		},
+	}
+
+	flagName := func(name string) string {
+		return "-" + name
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			group := newFlagGroup(test.kind, []string{"a", "b", "c"})
+			isChanged := func(name string) bool {
+				for _, set := range test.set {
+					if set == name {
+						return true
+					}
+				}
+				return false
+			}
+
+			failures := make(map[string]error)
+			group.validate(isChanged, flagName, failures)
+
+			actual := make(map[string]string)
+			for name, err := range failures {
+				actual[name] = err.Error()
+			}
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+
+	t.Run("existing failure", func(t *testing.T) {
+		group := newFlagGroup(atMostOneOf, []string{"a", "b"})
+		failures := map[string]error{"b": errRequired}
+		group.validate(func(string) bool { return true }, flagName, failures)
+		assert.Equal(t, map[string]error{"b": errRequired}, failures)
+	})
+
+	t.Run("display names", func(t *testing.T) {
+		group := newFlagGroup(exactlyOneOf, []string{"json", "yaml"})
+		failures := make(map[string]error)
+		group.validate(func(string) bool { return false }, posixFlagName, failures)
+		assert.EqualError(t, failures["json"], "or --yaml is required")
+	})
+}
+
+func Test_groupUsage(t *testing.T) {
+	flagName := func(name string) string {
+		return "-" + name
+	}
+
+	assert.Equal(t, "", groupUsage(nil, flagName))
+	assert.Equal(t, "\n"+
+		"  exactly one of: -file, -stdout\n"+
+		"  at most one of: -json, -yaml\n"+
+		"  all or none of: -user, -password\n", groupUsage([]*flagGroup{
+		newFlagGroup(exactlyOneOf, []string{"file", "stdout"}),
+		newFlagGroup(atMostOneOf, []string{"json", "yaml"}),
+		newFlagGroup(allOrNone, []string{"user", "password"}),
+	}, flagName))
+}
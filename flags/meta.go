@@ -0,0 +1,35 @@
+package flags
+
+// flagMeta contains the additional flag details that are shared between
+// a flagset and any sub flagsets.
+type flagMeta struct {
+	source     Source
+	required   bool
+	validators []Validator
+	envVars    []string
+	configKey  string
+	negatable  bool
+	local      bool
+	hidden     bool
+	fileValue  bool
+	// displayName is the flag name as it is used on the command-line, such as -name or --name
+	displayName string
+	// path is the route path of the flagset the flag was defined on
+	path    []string
+	aliases []string
+	// deprecated contains the deprecation messages keyed by flag name or alias
+	deprecated map[string]string
+}
+
+// deprecate marks the flag name or alias as deprecated with the message.
+func (meta *flagMeta) deprecate(name, message string) {
+	if meta.deprecated == nil {
+		meta.deprecated = make(map[string]string)
+	}
+	meta.deprecated[name] = message
+}
+
+// isSet returns true if the flag value has been set from any source other than the default value.
+func (meta *flagMeta) isSet() bool {
+	return meta.source != SourceDefault
+}
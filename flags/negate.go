@@ -0,0 +1,14 @@
+package flags
+
+import "fmt"
+
+// negationPrefix is the prefix used to set a negatable flag to false, such as -no-color.
+const negationPrefix = "no-"
+
+// negateUsage returns the negated flag name to be included in the flag usage.
+func negateUsage(meta *flagMeta, negatedName string) string {
+	if !meta.negatable {
+		return ""
+	}
+	return fmt.Sprintf(" (negate with %s)", negatedName)
+}
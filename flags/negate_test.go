@@ -0,0 +1,12 @@
+package flags
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_negateUsage(t *testing.T) {
+	assert.Equal(t, "", negateUsage(&flagMeta{}, "-no-color"))
+	assert.Equal(t, " (negate with -no-color)", negateUsage(&flagMeta{negatable: true}, "-no-color"))
+}
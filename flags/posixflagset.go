@@ -0,0 +1,473 @@
+package flags
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/evilmonkeyinc/golang-cli/errors"
+)
+
+// posixNames returns the flag names as they would be used on the command-line.
+func posixNames(f *definedFlag) string {
+	if f.shorthand != "" && f.name != f.shorthand {
+		return fmt.Sprintf("-%s, --%s", f.shorthand, f.name)
+	}
+	if f.shorthand != "" {
+		return fmt.Sprintf("-%s", f.shorthand)
+	}
+	return fmt.Sprintf("--%s", f.name)
+}
+
+// NewPosixFlagSet returns a new PosixFlagSet.
+func NewPosixFlagSet() *PosixFlagSet {
+	return &PosixFlagSet{}
+}
+
+// PosixFlagSet is a FlagSet implementation that supports POSIX/GNU-style flags.
+//
+// Flags are defined with a comma separated list of names, where single character
+// names are short flags, such as -v, and longer names are long flags, such as --verbose.
+// The first long name, or the short name if no long name is supplied, is the name
+// used to reference the flag, for example a flag defined as "verbose,v" can be set
+// using -v or --verbose and retrieved using either name.
+//
+// Long flags support the --flag=value and --flag value formats, short flags support
+// the -f value and -fvalue formats, and short bool flags can be bundled, such as -abc.
+// The -- argument will terminate flag parsing.
+type PosixFlagSet struct {
+	flagCore
+	name string
+	// flags contains the defined flags keyed by their long names and aliases
+	flags map[string]*definedFlag
+	// shorthands contains the defined flags keyed by their single character names and aliases
+	shorthands map[string]*definedFlag
+}
+
+func (flagSet *PosixFlagSet) setup() {
+	flagSet.flagCore.setup()
+	if flagSet.flags == nil {
+		flagSet.flags = make(map[string]*definedFlag)
+	}
+	if flagSet.shorthands == nil {
+		flagSet.shorthands = make(map[string]*definedFlag)
+	}
+}
+
+// add adds the flag to the flagset, and will panic if the name or shorthand has already been defined.
+func (flagSet *PosixFlagSet) add(f *definedFlag) {
+	flagSet.setup()
+	if _, exists := flagSet.flags[f.name]; exists {
+		panic(fmt.Sprintf("flag redefined: %s", f.name))
+	}
+	if _, exists := flagSet.shorthands[f.shorthand]; exists && f.shorthand != "" {
+		panic(fmt.Sprintf("flag redefined: %s", f.shorthand))
+	}
+	flagSet.flags[f.name] = f
+	if f.shorthand != "" {
+		flagSet.shorthands[f.shorthand] = f
+	}
+	flagSet.register(f)
+}
+
+// SubFlagSet creates a new flagset that will be used by sub routers and sub commands.
+func (flagSet *PosixFlagSet) SubFlagSet(name string) FlagSet {
+	flagSet.setup()
+
+	newFlagSet := &PosixFlagSet{
+		flagCore: flagSet.sub(name),
+		name:     name,
+	}
+	newFlagSet.setup()
+	for key, f := range flagSet.flags {
+		if newFlagSet.defined[f.name] == f {
+			newFlagSet.flags[key] = f
+		}
+	}
+	for key, f := range flagSet.shorthands {
+		if newFlagSet.defined[f.name] == f {
+			newFlagSet.shorthands[key] = f
+		}
+	}
+	return newFlagSet
+}
+
+// Parse parses flag definitions from the argument list, which should not include the command name, and return remaining, non-flag, arguments.
+// Must be called after all flags in the FlagSet are defined and before flags are accessed by the program.
+// The return value will be an HelpRequested error if -h or --help was set but not defined.
+func (flagSet *PosixFlagSet) Parse(args []string) ([]string, error) {
+	flagSet.setup()
+	flagSet.parsed = true
+	flagSet.warnings = nil
+
+	positional := []string{}
+	for len(args) > 0 {
+		arg := args[0]
+		if arg == "--" {
+			args = args[1:]
+			break
+		}
+		if len(arg) < 2 || arg[0] != '-' {
+			if !flagSet.interspersed {
+				break
+			}
+			positional = append(positional, arg)
+			args = args[1:]
+			continue
+		}
+		args = args[1:]
+
+		var err error
+		if strings.HasPrefix(arg, "--") {
+			args, err = flagSet.parseLong(arg[2:], args)
+		} else {
+			args, err = flagSet.parseShort(arg[1:], args)
+		}
+		if err != nil {
+			return append(positional, args...), err
+		}
+	}
+
+	if err := flagSet.applySources(); err != nil {
+		return append(positional, args...), err
+	}
+	return append(positional, args...), nil
+}
+
+// parseLong parses a single long flag, which may consume the next argument as its value.
+func (flagSet *PosixFlagSet) parseLong(arg string, args []string) ([]string, error) {
+	name, value, hasValue := arg, "", false
+	if index := strings.Index(arg, "="); index >= 0 {
+		name, value, hasValue = arg[:index], arg[index+1:], true
+	}
+
+	f, ok := flagSet.flags[name]
+	if !ok && !hasValue {
+		if f, ok = flagSet.negation(name); ok {
+			value, hasValue = "false", true
+		}
+	}
+	if !ok {
+		if name == "help" || name == "h" {
+			return args, errors.HelpRequested("flags")
+		}
+		return args, errors.FlagsetParseFailed(fmt.Sprintf("flag provided but not defined: --%s", name))
+	}
+
+	if !hasValue {
+		if isBoolFlag(f.value) {
+			value = "true"
+		} else if len(args) > 0 {
+			value, args = args[0], args[1:]
+		} else {
+			return args, errors.FlagsetParseFailed(fmt.Sprintf("flag needs an argument: --%s", name))
+		}
+	}
+
+	value, err := flagSet.fileValue(f, "--"+name, value)
+	if err != nil {
+		return args, err
+	}
+	if err := f.value.Set(value); err != nil {
+		return args, errors.FlagsetParseFailed(fmt.Sprintf("invalid value %q for flag --%s: %v", value, name, err))
+	}
+	flagSet.setByName(f, name, "--"+name)
+	return args, nil
+}
+
+// negation returns the negatable bool flag for the negated flag name, such as no-color.
+func (flagSet *PosixFlagSet) negation(name string) (*definedFlag, bool) {
+	if !strings.HasPrefix(name, negationPrefix) {
+		return nil, false
+	}
+	f, ok := flagSet.flags[strings.TrimPrefix(name, negationPrefix)]
+	if !ok || !isBoolFlag(f.value) || !flagSet.meta(f.name).negatable {
+		return nil, false
+	}
+	return f, true
+}
+
+// parseShort parses a group of one or more short flags, the last of which may consume
+// the remainder of the group or the next argument as its value.
+func (flagSet *PosixFlagSet) parseShort(arg string, args []string) ([]string, error) {
+	for i := 0; i < len(arg); i++ {
+		name := arg[i : i+1]
+		f, ok := flagSet.shorthands[name]
+		if !ok {
+			if name == "h" {
+				return args, errors.HelpRequested("flags")
+			}
+			return args, errors.FlagsetParseFailed(fmt.Sprintf("flag provided but not defined: -%s", name))
+		}
+
+		remainder := arg[i+1:]
+		if isBoolFlag(f.value) && !strings.HasPrefix(remainder, "=") {
+			if err := f.value.Set("true"); err != nil {
+				return args, errors.FlagsetParseFailed(fmt.Sprintf("invalid value %q for flag -%s: %v", "true", name, err))
+			}
+			flagSet.setByName(f, name, "-"+name)
+			continue
+		}
+
+		value := strings.TrimPrefix(remainder, "=")
+		if remainder == "" {
+			if len(args) == 0 {
+				return args, errors.FlagsetParseFailed(fmt.Sprintf("flag needs an argument: -%s", name))
+			}
+			value, args = args[0], args[1:]
+		}
+
+		value, err := flagSet.fileValue(f, "-"+name, value)
+		if err != nil {
+			return args, err
+		}
+		if err := f.value.Set(value); err != nil {
+			return args, errors.FlagsetParseFailed(fmt.Sprintf("invalid value %q for flag -%s: %v", value, name, err))
+		}
+		flagSet.setByName(f, name, "-"+name)
+		return args, nil
+	}
+	return args, nil
+}
+
+// Alias adds one or more alternate names for the named flag, which share the flag value.
+// Single character aliases are short flags and longer aliases are long flags.
+// Alias will panic if the flag has not been defined or if an alias has already been defined.
+func (flagSet *PosixFlagSet) Alias(name string, aliases ...string) {
+	f := flagSet.lookup(name)
+	if f == nil {
+		panic(fmt.Sprintf("flag %q is not defined", name))
+	}
+	flagSet.setup()
+	for _, alias := range aliases {
+		names := flagSet.flags
+		if len(alias) == 1 {
+			names = flagSet.shorthands
+		}
+		if _, exists := names[alias]; exists {
+			panic(fmt.Sprintf("flag redefined: %s", alias))
+		}
+		names[alias] = f
+		flagSet.alias(f.name, alias)
+	}
+}
+
+// DeprecatedAlias adds an alternate name for the named flag, which will produce a warning
+// containing the message, such as "use --output instead", whenever the alias is used.
+func (flagSet *PosixFlagSet) DeprecatedAlias(name string, alias string, message string) {
+	flagSet.Alias(name, alias)
+	flagSet.meta(name).deprecate(alias, message)
+}
+
+// Bool defines a bool flag with specified name, default value, and usage string.
+func (flagSet *PosixFlagSet) Bool(name string, defaultValue bool, usage string) {
+	flagSet.Var(newBoolValue(defaultValue), name, usage)
+}
+
+// Int defines a int64 flag with specified name, default value, and usage string.
+func (flagSet *PosixFlagSet) Int(name string, defaultValue int64, usage string) {
+	flagSet.Var(newIntValue(defaultValue), name, usage)
+}
+
+// Uint defines a unit64 flag with specified name, default value, and usage string.
+func (flagSet *PosixFlagSet) Uint(name string, defaultValue uint64, usage string) {
+	flagSet.Var(newUintValue(defaultValue), name, usage)
+}
+
+// String defines a string flag with specified name, default value, and usage string.
+func (flagSet *PosixFlagSet) String(name string, defaultValue string, usage string) {
+	flagSet.Var(newStringValue(defaultValue), name, usage)
+}
+
+// StringArray defines a string array flag with specified name, default value, and usage string.
+func (flagSet *PosixFlagSet) StringArray(name string, defaultValue []string, usage string) {
+	value := &StringArrayFlag{}
+	if len(defaultValue) > 0 {
+		value.Set(strings.Join(defaultValue, ","))
+	}
+	flagSet.Var(value, name, usage)
+}
+
+// Float defines a float64 flag with specified name, default value, and usage string.
+func (flagSet *PosixFlagSet) Float(name string, defaultValue float64, usage string) {
+	flagSet.Var(newFloatValue(defaultValue), name, usage)
+}
+
+// Duration defines a time.Duration flag with specified name, default value, and usage string.
+func (flagSet *PosixFlagSet) Duration(name string, defaultValue time.Duration, usage string) {
+	flagSet.Var(newDurationValue(defaultValue), name, usage)
+}
+
+// Count defines an int64 flag with specified name and usage string, which does not require
+// an argument and is incremented each time it is used, such as -vvv. The flag is retrieved using GetInt.
+func (flagSet *PosixFlagSet) Count(name string, usage string) {
+	flagSet.Var(newCountValue(), name, usage)
+}
+
+// Enum defines a string flag with specified name, default value, choices, and usage string.
+// The flag can only be set to one of the choices, which are included in the flag usage.
+func (flagSet *PosixFlagSet) Enum(name string, defaultValue string, choices []string, usage string) {
+	flagSet.Var(newEnumValue(defaultValue, choices), name, usage)
+}
+
+// IntSlice defines a int64 slice flag with specified name, default value, and usage string.
+// The flag can be used multiple times or with a comma separated list.
+func (flagSet *PosixFlagSet) IntSlice(name string, defaultValue []int64, usage string) {
+	flagSet.Var(newIntSliceValue(defaultValue), name, usage)
+}
+
+// UintSlice defines a uint64 slice flag with specified name, default value, and usage string.
+// The flag can be used multiple times or with a comma separated list.
+func (flagSet *PosixFlagSet) UintSlice(name string, defaultValue []uint64, usage string) {
+	flagSet.Var(newUintSliceValue(defaultValue), name, usage)
+}
+
+// FloatSlice defines a float64 slice flag with specified name, default value, and usage string.
+// The flag can be used multiple times or with a comma separated list.
+func (flagSet *PosixFlagSet) FloatSlice(name string, defaultValue []float64, usage string) {
+	flagSet.Var(newFloatSliceValue(defaultValue), name, usage)
+}
+
+// StringMap defines a map[string]string flag with specified name, default value, and usage string.
+// The flag is set using key=value pairs, and can be used multiple times or with a comma separated list.
+func (flagSet *PosixFlagSet) StringMap(name string, defaultValue map[string]string, usage string) {
+	flagSet.Var(newStringMapValue(defaultValue), name, usage)
+}
+
+// Time defines a time.Time flag with specified name, default value, layout, and usage string.
+// The layout is used to parse the flag value, as described by time.Parse.
+func (flagSet *PosixFlagSet) Time(name string, defaultValue time.Time, layout string, usage string) {
+	flagSet.Var(newTimeValue(defaultValue, layout), name, usage)
+}
+
+// IP defines a net.IP flag with specified name, default value, and usage string.
+func (flagSet *PosixFlagSet) IP(name string, defaultValue net.IP, usage string) {
+	flagSet.Var(newIPValue(defaultValue), name, usage)
+}
+
+// IPNet defines a net.IPNet flag with specified name, default value, and usage string.
+// The flag is set using CIDR notation, such as 192.168.0.0/16.
+func (flagSet *PosixFlagSet) IPNet(name string, defaultValue net.IPNet, usage string) {
+	flagSet.Var(newIPNetValue(defaultValue), name, usage)
+}
+
+// URL defines a *url.URL flag with specified name, default value, and usage string.
+func (flagSet *PosixFlagSet) URL(name string, defaultValue *url.URL, usage string) {
+	flagSet.Var(newURLValue(defaultValue), name, usage)
+}
+
+// ByteSize defines a uint64 flag with specified name, default value, and usage string.
+// The flag is set using a byte size, such as 512, 1.5GB, or 10MiB, and is retrieved using GetUint.
+func (flagSet *PosixFlagSet) ByteSize(name string, defaultValue uint64, usage string) {
+	flagSet.Var(newByteSizeValue(defaultValue), name, usage)
+}
+
+// Regexp defines a *regexp.Regexp flag with specified name, default value, and usage string.
+func (flagSet *PosixFlagSet) Regexp(name string, defaultValue *regexp.Regexp, usage string) {
+	flagSet.Var(newRegexpValue(defaultValue), name, usage)
+}
+
+// Secret defines a string flag with specified name and usage string, whose value is redacted
+// whenever it is displayed. The flag is retrieved using GetString.
+func (flagSet *PosixFlagSet) Secret(name string, usage string) {
+	flagSet.Var(new(secretValue), name, usage)
+}
+
+// Path defines a string flag with specified name, default value, path check, and usage string.
+// The path check is performed whenever the flag is set, and the flag is retrieved using GetString.
+func (flagSet *PosixFlagSet) Path(name string, defaultValue string, check PathCheck, usage string) {
+	flagSet.Var(newPathValue(defaultValue, check), name, usage)
+}
+
+// Var defines a flag with the specified name and usage string.
+// The type and value of the flag are represented by the first argument,
+// of type Value, which typically holds a user-defined implementation of Value.
+//
+// The name can be a comma separated list containing a long name and a single character shorthand.
+func (flagSet *PosixFlagSet) Var(value Value, name, usage string) {
+	f := &definedFlag{
+		usage:    usage,
+		value:    value,
+		defValue: value.String(),
+	}
+	for _, part := range strings.Split(name, ",") {
+		part = strings.TrimSpace(part)
+		if len(part) == 1 && f.shorthand == "" {
+			f.shorthand = part
+		} else if f.name == "" {
+			f.name = part
+		} else {
+			panic(fmt.Sprintf("flag %q has too many names", name))
+		}
+	}
+	if f.name == "" {
+		f.name = f.shorthand
+	}
+	f.displayName = posixFlagName(f.name)
+	flagSet.add(f)
+}
+
+// DefaultUsage returns a usage message showing the default
+// settings of all defined command-line flags.
+func (flagSet *PosixFlagSet) DefaultUsage() string {
+	buffer := &bytes.Buffer{}
+	for _, f := range flagSet.sortedFlags() {
+		if flagSet.meta(f.name).hidden {
+			continue
+		}
+		fmt.Fprintf(buffer, "  %s%s", posixNames(f), aliasNames(flagSet.meta(f.name).aliases, posixFlagName))
+		valueName, usage := unquoteUsage(f.value, f.usage)
+		if valueName != "" {
+			fmt.Fprintf(buffer, " %s", valueName)
+		}
+		fmt.Fprintf(buffer, "\n    \t%s", strings.ReplaceAll(usage, "\n", "\n    \t"))
+		fmt.Fprint(buffer, choicesUsage(f.value))
+		fmt.Fprint(buffer, negateUsage(flagSet.meta(f.name), "--"+negationPrefix+f.name))
+		fmt.Fprint(buffer, envUsage(envVars(flagSet.meta(f.name), flagSet.envPrefix, f.name)))
+		if !isZeroValue(f.defValue) {
+			if _, ok := f.value.(*stringValue); ok {
+				fmt.Fprintf(buffer, " (default %q)", f.defValue)
+			} else {
+				fmt.Fprintf(buffer, " (default %v)", f.defValue)
+			}
+		}
+		fmt.Fprint(buffer, "\n")
+	}
+	buffer.WriteString(groupUsage(flagSet.groups, func(name string) string {
+		if f := flagSet.lookup(name); f != nil && f.name == f.shorthand {
+			return "-" + f.shorthand
+		}
+		return "--" + name
+	}))
+	return buffer.String()
+}
+
+// unquoteUsage extracts a back-quoted name from the usage string for a flag
+// and returns it and the un-quoted usage, or a name based on the value type.
+func unquoteUsage(value Value, usage string) (string, string) {
+	if first := strings.Index(usage, "`"); first >= 0 {
+		if last := strings.Index(usage[first+1:], "`"); last >= 0 {
+			last += first + 1
+			name := usage[first+1 : last]
+			return name, usage[:first] + name + usage[last+1:]
+		}
+	}
+
+	if isBoolFlag(value) {
+		return "", usage
+	}
+	return typeName(value), usage
+}
+
+// isZeroValue determines whether the string represents the zero value for a flag.
+func isZeroValue(defValue string) bool {
+	switch defValue {
+	case "", "0", "false", "0s":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,1010 @@
+package flags
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/evilmonkeyinc/golang-cli/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+// Validate the PosixFlagSet struct matches the FlagSet interface
+var _ FlagSet = &PosixFlagSet{}
+
+func Test_NewPosixFlagSet(t *testing.T) {
+	actual := NewPosixFlagSet()
+	assert.IsType(t, &PosixFlagSet{}, actual)
+	assert.False(t, actual.Parsed())
+}
+
+func Test_PosixFlagSet_Var(t *testing.T) {
+
+	tests := []struct {
+		name      string
+		input     string
+		flagName  string
+		shorthand string
+	}{
+		{
+			name:     "long",
+			input:    "verbose",
+			flagName: "verbose",
+		},
+		{
+			name:      "short",
+			input:     "v",
+			flagName:  "v",
+			shorthand: "v",
+		},
+		{
+			name:      "long and short",
+			input:     "verbose,v",
+			flagName:  "verbose",
+			shorthand: "v",
+		},
+		{
+			name:      "short and long",
+			input:     "v, verbose",
+			flagName:  "verbose",
+			shorthand: "v",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			flagSet := NewPosixFlagSet()
+			flagSet.Bool(test.input, false, "")
+
+			f := flagSet.lookup(test.flagName)
+			assert.NotNil(t, f)
+			assert.Equal(t, test.flagName, f.name)
+			assert.Equal(t, test.shorthand, f.shorthand)
+		})
+	}
+
+	t.Run("redefined", func(t *testing.T) {
+		testPanic(t, func() {
+			flagSet := NewPosixFlagSet()
+			flagSet.Bool("verbose,v", false, "")
+			flagSet.Bool("verbose", false, "")
+		}, "flag redefined: verbose")
+	})
+
+	t.Run("redefined shorthand", func(t *testing.T) {
+		testPanic(t, func() {
+			flagSet := NewPosixFlagSet()
+			flagSet.Bool("verbose,v", false, "")
+			flagSet.Bool("version,v", false, "")
+		}, "flag redefined: v")
+	})
+
+	t.Run("too many names", func(t *testing.T) {
+		testPanic(t, func() {
+			flagSet := NewPosixFlagSet()
+			flagSet.Bool("verbose,v,loud", false, "")
+		}, "flag \"verbose,v,loud\" has too many names")
+	})
+}
+
+func Test_PosixFlagSet_Parse(t *testing.T) {
+
+	type expected struct {
+		args   []string
+		err    error
+		values map[string]interface{}
+	}
+
+	tests := []struct {
+		name     string
+		input    []string
+		expected expected
+	}{
+		{
+			name:  "no flags",
+			input: []string{"ping"},
+			expected: expected{
+				args: []string{"ping"},
+			},
+		},
+		{
+			name:  "long bool",
+			input: []string{"--verbose", "ping"},
+			expected: expected{
+				args:   []string{"ping"},
+				values: map[string]interface{}{"verbose": true},
+			},
+		},
+		{
+			name:  "long bool value",
+			input: []string{"--verbose=false", "ping"},
+			expected: expected{
+				args:   []string{"ping"},
+				values: map[string]interface{}{"verbose": false},
+			},
+		},
+		{
+			name:  "long equals",
+			input: []string{"--name=value", "ping"},
+			expected: expected{
+				args:   []string{"ping"},
+				values: map[string]interface{}{"name": "value"},
+			},
+		},
+		{
+			name:  "long separate",
+			input: []string{"--name", "value", "ping"},
+			expected: expected{
+				args:   []string{"ping"},
+				values: map[string]interface{}{"name": "value"},
+			},
+		},
+		{
+			name:  "long empty value",
+			input: []string{"--name=", "ping"},
+			expected: expected{
+				args:   []string{"ping"},
+				values: map[string]interface{}{"name": ""},
+			},
+		},
+		{
+			name:  "short separate",
+			input: []string{"-n", "value", "ping"},
+			expected: expected{
+				args:   []string{"ping"},
+				values: map[string]interface{}{"name": "value"},
+			},
+		},
+		{
+			name:  "short attached",
+			input: []string{"-nvalue", "ping"},
+			expected: expected{
+				args:   []string{"ping"},
+				values: map[string]interface{}{"name": "value"},
+			},
+		},
+		{
+			name:  "short equals",
+			input: []string{"-n=value", "ping"},
+			expected: expected{
+				args:   []string{"ping"},
+				values: map[string]interface{}{"name": "value"},
+			},
+		},
+		{
+			name:  "bundled bools",
+			input: []string{"-va", "ping"},
+			expected: expected{
+				args:   []string{"ping"},
+				values: map[string]interface{}{"verbose": true, "all": true},
+			},
+		},
+		{
+			name:  "bundled bools with value",
+			input: []string{"-vancount", "ping"},
+			expected: expected{
+				args:   []string{"ping"},
+				values: map[string]interface{}{"verbose": true, "all": true, "name": "count"},
+			},
+		},
+		{
+			name:  "short int",
+			input: []string{"-c", "3", "ping"},
+			expected: expected{
+				args:   []string{"ping"},
+				values: map[string]interface{}{"count": int64(3)},
+			},
+		},
+		{
+			name:  "stop at first non-flag",
+			input: []string{"-v", "ping", "--all"},
+			expected: expected{
+				args:   []string{"ping", "--all"},
+				values: map[string]interface{}{"verbose": true, "all": false},
+			},
+		},
+		{
+			name:  "terminator",
+			input: []string{"-v", "--", "--all", "ping"},
+			expected: expected{
+				args:   []string{"--all", "ping"},
+				values: map[string]interface{}{"verbose": true, "all": false},
+			},
+		},
+		{
+			name:  "single dash",
+			input: []string{"-", "ping"},
+			expected: expected{
+				args: []string{"-", "ping"},
+			},
+		},
+		{
+			name:  "short help",
+			input: []string{"-h"},
+			expected: expected{
+				args: []string{},
+				err:  errors.HelpRequested("flags"),
+			},
+		},
+		{
+			name:  "long help",
+			input: []string{"--help"},
+			expected: expected{
+				args: []string{},
+				err:  errors.HelpRequested("flags"),
+			},
+		},
+		{
+			name:  "unknown long",
+			input: []string{"--unknown", "ping"},
+			expected: expected{
+				args: []string{"ping"},
+				err:  errors.FlagsetParseFailed("flag provided but not defined: --unknown"),
+			},
+		},
+		{
+			name:  "unknown short",
+			input: []string{"-vx", "ping"},
+			expected: expected{
+				args: []string{"ping"},
+				err:  errors.FlagsetParseFailed("flag provided but not defined: -x"),
+			},
+		},
+		{
+			name:  "missing long argument",
+			input: []string{"--name"},
+			expected: expected{
+				args: []string{},
+				err:  errors.FlagsetParseFailed("flag needs an argument: --name"),
+			},
+		},
+		{
+			name:  "missing short argument",
+			input: []string{"-n"},
+			expected: expected{
+				args: []string{},
+				err:  errors.FlagsetParseFailed("flag needs an argument: -n"),
+			},
+		},
+		{
+			name:  "invalid long value",
+			input: []string{"--count", "many", "ping"},
+			expected: expected{
+				args: []string{"ping"},
+				err:  errors.FlagsetParseFailed("invalid value \"many\" for flag --count: parse error"),
+			},
+		},
+		{
+			name:  "invalid short value",
+			input: []string{"-cmany", "ping"},
+			expected: expected{
+				args: []string{"ping"},
+				err:  errors.FlagsetParseFailed("invalid value \"many\" for flag -c: parse error"),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			flagSet := NewPosixFlagSet()
+			flagSet.Bool("verbose,v", false, "")
+			flagSet.Bool("all,a", false, "")
+			flagSet.String("name,n", "", "")
+			flagSet.Int("count,c", 0, "")
+
+			args, err := flagSet.Parse(test.input)
+			assert.True(t, flagSet.Parsed())
+			assert.Equal(t, test.expected.args, args)
+			assert.Equal(t, test.expected.err, err)
+			for key, expected := range test.expected.values {
+				assert.Equal(t, expected, flagSet.Get(key), key)
+			}
+		})
+	}
+}
+
+func Test_PosixFlagSet_SubFlagSet(t *testing.T) {
+	flagSet := NewPosixFlagSet()
+	flagSet.Bool("verbose,v", false, "")
+
+	args, err := flagSet.Parse([]string{"sub", "-v", "--name", "value", "arg"})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"sub", "-v", "--name", "value", "arg"}, args)
+
+	subFlagSet := flagSet.SubFlagSet("sub")
+	subFlagSet.String("name,n", "", "")
+
+	args, err = subFlagSet.Parse(args[1:])
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"arg"}, args)
+
+	assert.Equal(t, true, subFlagSet.Get("verbose"))
+	assert.Equal(t, "value", subFlagSet.Get("n"))
+
+	// values are shared with the parent flagset, but definitions are not
+	assert.Equal(t, true, flagSet.Get("v"))
+	assert.Nil(t, flagSet.Get("name"))
+}
+
+func Test_PosixFlagSet_Set(t *testing.T) {
+	flagSet := NewPosixFlagSet()
+	flagSet.Bool("verbose,v", false, "")
+
+	assert.Nil(t, flagSet.Set("v", "true"))
+	assert.Equal(t, true, flagSet.Get("verbose"))
+
+	assert.Equal(t, errors.FlagsetSetFailed("parse error"), flagSet.Set("verbose", "ok"))
+	assert.Equal(t, errors.FlagsetSetFailed("no such flag -missing"), flagSet.Set("missing", "ok"))
+}
+
+func Test_PosixFlagSet_Get(t *testing.T) {
+	flagSet := NewPosixFlagSet()
+	flagSet.Bool("bool", true, "")
+	flagSet.Int("int", -1, "")
+	flagSet.Uint("uint", 1, "")
+	flagSet.String("string", "value", "")
+	flagSet.StringArray("array", []string{"one", "two"}, "")
+	flagSet.Float("float", 1.5, "")
+	flagSet.Duration("duration", time.Second, "")
+
+	assert.Nil(t, flagSet.Get("missing"))
+
+	boolValue, ok := flagSet.GetBool("bool")
+	assert.True(t, ok)
+	assert.True(t, boolValue)
+
+	intValue, ok := flagSet.GetInt("int")
+	assert.True(t, ok)
+	assert.Equal(t, int64(-1), intValue)
+
+	uintValue, ok := flagSet.GetUint("uint")
+	assert.True(t, ok)
+	assert.Equal(t, uint64(1), uintValue)
+
+	stringValue, ok := flagSet.GetString("string")
+	assert.True(t, ok)
+	assert.Equal(t, "value", stringValue)
+
+	arrayValue, ok := flagSet.GetStringArray("array")
+	assert.True(t, ok)
+	assert.Equal(t, []string{"one", "two"}, arrayValue)
+
+	floatValue, ok := flagSet.GetFloat("float")
+	assert.True(t, ok)
+	assert.Equal(t, 1.5, floatValue)
+
+	durationValue, ok := flagSet.GetDuration("duration")
+	assert.True(t, ok)
+	assert.Equal(t, time.Second, durationValue)
+
+	_, ok = flagSet.GetBool("string")
+	assert.False(t, ok)
+}
+
+func Test_PosixFlagSet_VisitAll(t *testing.T) {
+	flagSet := NewPosixFlagSet()
+	flagSet.String("name,n", "value", "")
+	flagSet.Bool("v", true, "")
+
+	actual := []string{}
+	flagSet.VisitAll(func(name string, value Value) {
+		actual = append(actual, fmt.Sprintf("%s=%s", name, value.String()))
+	})
+	assert.Equal(t, []string{"name=value", "v=true"}, actual)
+}
+
+func Test_PosixFlagSet_DefaultUsage(t *testing.T) {
+	flagSet := NewPosixFlagSet()
+	flagSet.Bool("verbose,v", false, "verbose output")
+	flagSet.Bool("q", false, "quiet output")
+	flagSet.String("name", "default", "the `user` name")
+	flagSet.Int("count,c", 3, "the count")
+	flagSet.Duration("timeout", 0, "the timeout")
+
+	assert.Equal(t, "  -c, --count int\n"+
+		"    \tthe count (default 3)\n"+
+		"  --name user\n"+
+		"    \tthe user name (default \"default\")\n"+
+		"  -q\n"+
+		"    \tquiet output\n"+
+		"  --timeout duration\n"+
+		"    \tthe timeout\n"+
+		"  -v, --verbose\n"+
+		"    \tverbose output\n", flagSet.DefaultUsage())
+}
+
+func Test_PosixFlagSet_DefaultUsage_Groups(t *testing.T) {
+	flagSet := NewPosixFlagSet()
+	flagSet.Bool("json,j", false, "json output")
+	flagSet.Bool("y", false, "yaml output")
+	flagSet.AtMostOneOf("j", "y")
+
+	assert.Equal(t, "  -j, --json\n"+
+		"    \tjson output\n"+
+		"  -y\n"+
+		"    \tyaml output\n"+
+		"\n  at most one of: --json, -y\n", flagSet.DefaultUsage())
+}
+
+func Test_PosixFlagSet_Groups(t *testing.T) {
+	flagSet := NewPosixFlagSet()
+	flagSet.Bool("json,j", false, "")
+	flagSet.Bool("yaml,y", false, "")
+	flagSet.AtMostOneOf("j", "yaml")
+
+	_, err := flagSet.SubFlagSet("sub").Parse([]string{"-jy"})
+	assert.Nil(t, err)
+	assert.EqualError(t, flagSet.Validate(), "flagset validation failed: --yaml cannot be used with --json")
+}
+
+// testPanic is a helper function so we can unit test functions that panic
+func testPanic(t *testing.T, fn func(), expectedErrMsg string) {
+	defer func() {
+		if rvr := recover(); rvr != nil {
+			errMsg := fmt.Sprintf("%v", rvr)
+			assert.Equal(t, expectedErrMsg, errMsg)
+			return
+		}
+		assert.Fail(t, "function should have panicked")
+	}()
+	fn()
+}
+
+func Test_PosixFlagSet_Interspersed(t *testing.T) {
+
+	tests := []struct {
+		name          string
+		input         []string
+		expected      []string
+		expectedError error
+		values        map[string]interface{}
+	}{
+		{
+			name:     "flags after args",
+			input:    []string{"bob", "-a", "--role", "owner", "other"},
+			expected: []string{"bob", "other"},
+			values: map[string]interface{}{
+				"admin": true,
+				"role":  "owner",
+			},
+		},
+		{
+			name:     "terminator",
+			input:    []string{"bob", "--admin", "--", "-r", "owner"},
+			expected: []string{"bob", "-r", "owner"},
+			values: map[string]interface{}{
+				"admin": true,
+				"role":  "",
+			},
+		},
+		{
+			name:          "unknown flag",
+			input:         []string{"bob", "-x", "value"},
+			expected:      []string{"bob", "value"},
+			expectedError: errors.FlagsetParseFailed("flag provided but not defined: -x"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			flagSet := NewPosixFlagSet()
+			assert.False(t, flagSet.Interspersed())
+			flagSet.SetInterspersed(true)
+			assert.True(t, flagSet.Interspersed())
+
+			flagSet.Bool("admin,a", false, "")
+			flagSet.String("role,r", "", "")
+
+			actual, actualError := flagSet.Parse(test.input)
+			assert.Equal(t, test.expected, actual)
+			assert.Equal(t, test.expectedError, actualError)
+			for key, expected := range test.values {
+				assert.Equal(t, expected, flagSet.Get(key), key)
+			}
+		})
+	}
+
+	t.Run("SubFlagSet", func(t *testing.T) {
+		flagSet := NewPosixFlagSet()
+		flagSet.SetInterspersed(true)
+		assert.True(t, flagSet.SubFlagSet("sub").Interspersed())
+	})
+}
+
+func Test_PosixFlagSet_Validate(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		args     []string
+		set      map[string]string
+		expected string
+	}{
+		{
+			name:     "missing required",
+			args:     []string{},
+			expected: "flagset validation failed: --name is required",
+		},
+		{
+			name:     "valid",
+			args:     []string{"-n", "bob", "--count=2"},
+			expected: "",
+		},
+		{
+			name:     "invalid values",
+			args:     []string{"--name", "Bob", "-c0"},
+			expected: "flagset validation failed: --count must be at least 1, --name must match \"^[a-z]+$\"",
+		},
+		{
+			name:     "set by parent",
+			args:     []string{},
+			set:      map[string]string{"n": "bob"},
+			expected: "",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			parent := NewPosixFlagSet()
+			parent.String("name,n", "", "")
+			parent.Required("n")
+			parent.Validators("name", Pattern("^[a-z]+$"))
+			for name, value := range test.set {
+				assert.Nil(t, parent.Set(name, value))
+			}
+
+			flagSet := parent.SubFlagSet("sub")
+			flagSet.Int("count,c", 1, "")
+			flagSet.Validators("count", Min(1))
+
+			_, err := flagSet.Parse(test.args)
+			assert.Nil(t, err)
+
+			actual := flagSet.Validate()
+			if test.expected == "" {
+				assert.Nil(t, actual)
+			} else {
+				assert.True(t, errors.IsFlagsetValidationFailed(actual))
+				assert.EqualError(t, actual, test.expected)
+			}
+		})
+	}
+}
+
+func Test_PosixFlagSet_Env(t *testing.T) {
+	t.Setenv("MYCLI_PORT", "8080")
+	t.Setenv("TEST_VERBOSE", "true")
+	t.Setenv("MYCLI_HOST", "env-host")
+
+	flagSet := NewPosixFlagSet()
+	flagSet.SetEnvPrefix("MYCLI_")
+	flagSet.Int("port,p", 80, "the port")
+	flagSet.Bool("v", false, "verbose output")
+	flagSet.Env("v", "TEST_VERBOSE")
+	flagSet.String("host", "", "the host")
+
+	sub := flagSet.SubFlagSet("sub")
+	_, err := sub.Parse([]string{"--host", "cli-host"})
+	assert.Nil(t, err)
+
+	port, _ := sub.GetInt("p")
+	assert.Equal(t, int64(8080), port)
+	assert.Equal(t, SourceEnvironment, sub.Source("p"))
+	verbose, _ := sub.GetBool("v")
+	assert.True(t, verbose)
+	host, _ := sub.GetString("host")
+	assert.Equal(t, "cli-host", host)
+	assert.Equal(t, SourceCommandLine, flagSet.Source("host"))
+
+	assert.Equal(t, "  --host string\n"+
+		"    \tthe host [$MYCLI_HOST]\n"+
+		"  -p, --port int\n"+
+		"    \tthe port [$MYCLI_PORT] (default 80)\n"+
+		"  -v\n"+
+		"    \tverbose output [$TEST_VERBOSE, $MYCLI_V]\n", flagSet.DefaultUsage())
+
+	t.Run("invalid", func(t *testing.T) {
+		t.Setenv("MYCLI_PORT", "abc")
+		flagSet := NewPosixFlagSet()
+		flagSet.SetEnvPrefix("MYCLI_")
+		flagSet.Int("port", 80, "")
+		_, err := flagSet.Parse([]string{})
+		assert.EqualError(t, err, "flagset parse failed invalid value \"abc\" for flag --port from $MYCLI_PORT: parse error")
+	})
+}
+
+func Test_PosixFlagSet_Config(t *testing.T) {
+	source := testConfig{
+		"verbose":        "true",
+		"users.add.role": "admin",
+	}
+
+	root := NewPosixFlagSet()
+	root.Bool("verbose,v", false, "")
+	root.String("role,r", "", "")
+	assert.Nil(t, root.SetConfig(source))
+
+	add := root.SubFlagSet("users").SubFlagSet("add")
+	_, err := add.Parse([]string{})
+	assert.Nil(t, err)
+
+	verbose, _ := add.GetBool("v")
+	assert.True(t, verbose)
+	role, _ := add.GetString("r")
+	assert.Equal(t, "admin", role)
+	assert.Equal(t, SourceConfig, add.Source("r"))
+	assert.Equal(t, source, add.Config())
+
+	t.Run("invalid", func(t *testing.T) {
+		flagSet := NewPosixFlagSet()
+		flagSet.Int("count", 0, "")
+		err := flagSet.SetConfig(testConfig{"count": "abc"})
+		assert.EqualError(t, err, "flagset parse failed invalid value \"abc\" for flag --count from config count: parse error")
+	})
+}
+
+func Test_PosixFlagSet_Types(t *testing.T) {
+	flagSet := NewPosixFlagSet()
+	flagSet.IntSlice("ints", []int64{1}, "")
+	flagSet.UintSlice("uints", nil, "")
+	flagSet.FloatSlice("floats", nil, "")
+	flagSet.StringMap("labels", nil, "")
+	flagSet.Time("since", time.Time{}, "2006-01-02", "")
+	flagSet.IP("ip", nil, "")
+	flagSet.IPNet("network", net.IPNet{}, "")
+	flagSet.URL("url", nil, "")
+	flagSet.ByteSize("size", 1024, "")
+	flagSet.Regexp("pattern", nil, "")
+	flagSet.Path("dir", ".", PathDir, "")
+
+	_, err := flagSet.Parse([]string{
+		"--ints", "2,3",
+		"--uints", "4",
+		"--floats", "1.5",
+		"--labels", "env=prod",
+		"--since", "2021-03-04",
+		"--ip", "10.0.0.1",
+		"--network", "10.0.0.0/8",
+		"--url", "https://example.com",
+		"--size", "2MiB",
+		"--pattern", "^a+$",
+	})
+	assert.Nil(t, err)
+
+	ints, ok := flagSet.GetIntSlice("ints")
+	assert.True(t, ok)
+	assert.Equal(t, []int64{2, 3}, ints)
+
+	uints, ok := flagSet.GetUintSlice("uints")
+	assert.True(t, ok)
+	assert.Equal(t, []uint64{4}, uints)
+
+	floats, ok := flagSet.GetFloatSlice("floats")
+	assert.True(t, ok)
+	assert.Equal(t, []float64{1.5}, floats)
+
+	labels, ok := flagSet.GetStringMap("labels")
+	assert.True(t, ok)
+	assert.Equal(t, map[string]string{"env": "prod"}, labels)
+
+	since, ok := flagSet.GetTime("since")
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2021, 3, 4, 0, 0, 0, 0, time.UTC), since)
+
+	ip, ok := flagSet.GetIP("ip")
+	assert.True(t, ok)
+	assert.Equal(t, "10.0.0.1", ip.String())
+
+	network, ok := flagSet.GetIPNet("network")
+	assert.True(t, ok)
+	assert.Equal(t, "10.0.0.0/8", network.String())
+
+	address, ok := flagSet.GetURL("url")
+	assert.True(t, ok)
+	assert.Equal(t, "example.com", address.Host)
+
+	size, ok := flagSet.GetUint("size")
+	assert.True(t, ok)
+	assert.Equal(t, uint64(2<<20), size)
+
+	pattern, ok := flagSet.GetRegexp("pattern")
+	assert.True(t, ok)
+	assert.True(t, pattern.MatchString("aaa"))
+
+	dir, ok := flagSet.GetString("dir")
+	assert.True(t, ok)
+	assert.Equal(t, ".", dir)
+
+	assert.NotNil(t, flagSet.Set("dir", "missing-directory"))
+
+	_, ok = flagSet.GetIntSlice("uints")
+	assert.False(t, ok)
+}
+
+func Test_PosixFlagSet_Enum(t *testing.T) {
+	flagSet := NewPosixFlagSet()
+	flagSet.Enum("output,o", "table", []string{"json", "yaml", "table"}, "the output format")
+
+	assert.Equal(t, "  -o, --output string\n"+
+		"    \tthe output format (one of: json, yaml, table) (default table)\n", flagSet.DefaultUsage())
+
+	_, err := flagSet.Parse([]string{"-o", "xml"})
+	assert.EqualError(t, err, "flagset parse failed invalid value \"xml\" for flag -o: must be one of: json, yaml, table")
+
+	_, err = flagSet.Parse([]string{"--output=yaml"})
+	assert.Nil(t, err)
+	value, ok := flagSet.GetString("o")
+	assert.True(t, ok)
+	assert.Equal(t, "yaml", value)
+}
+
+func Test_PosixFlagSet_Count(t *testing.T) {
+	flagSet := NewPosixFlagSet()
+	flagSet.Count("verbose,v", "verbosity")
+	flagSet.Bool("q", false, "quiet")
+
+	assert.Equal(t, "  -q\n"+
+		"    \tquiet\n"+
+		"  -v, --verbose\n"+
+		"    \tverbosity\n", flagSet.DefaultUsage())
+
+	_, err := flagSet.Parse([]string{"-vqv", "--verbose", "-v"})
+	assert.Nil(t, err)
+	value, ok := flagSet.GetInt("verbose")
+	assert.True(t, ok)
+	assert.Equal(t, int64(4), value)
+
+	_, err = flagSet.Parse([]string{"--verbose=0"})
+	assert.Nil(t, err)
+	value, _ = flagSet.GetInt("v")
+	assert.Equal(t, int64(0), value)
+}
+
+func Test_PosixFlagSet_Negatable(t *testing.T) {
+	flagSet := NewPosixFlagSet()
+	flagSet.Bool("color,c", true, "colored output")
+	flagSet.Negatable("c")
+	flagSet.Bool("debug", false, "debug output")
+
+	assert.Equal(t, "  -c, --color\n"+
+		"    \tcolored output (negate with --no-color) (default true)\n"+
+		"  --debug\n"+
+		"    \tdebug output\n", flagSet.DefaultUsage())
+
+	subFlagSet := flagSet.SubFlagSet("sub")
+	_, err := subFlagSet.Parse([]string{"--no-color"})
+	assert.Nil(t, err)
+	value, _ := subFlagSet.GetBool("color")
+	assert.False(t, value)
+	assert.Equal(t, SourceCommandLine, subFlagSet.Source("c"))
+
+	_, err = subFlagSet.Parse([]string{"--no-color=true"})
+	assert.EqualError(t, err, "flagset parse failed flag provided but not defined: --no-color")
+
+	_, err = subFlagSet.Parse([]string{"--no-debug"})
+	assert.EqualError(t, err, "flagset parse failed flag provided but not defined: --no-debug")
+}
+
+func Test_PosixFlagSet_Changed(t *testing.T) {
+	flagSet := NewPosixFlagSet()
+	flagSet.String("name", "default", "")
+	flagSet.Bool("debug", false, "")
+
+	assert.False(t, flagSet.Changed("name"))
+	assert.False(t, flagSet.Changed("missing"))
+
+	_, err := flagSet.Parse([]string{"--name", "default"})
+	assert.Nil(t, err)
+	assert.True(t, flagSet.Changed("name"))
+	assert.False(t, flagSet.Changed("debug"))
+
+	subFlagSet := flagSet.SubFlagSet("sub")
+	assert.True(t, subFlagSet.Changed("name"))
+	assert.False(t, subFlagSet.Changed("debug"))
+
+	assert.Nil(t, subFlagSet.Set("debug", "false"))
+	assert.True(t, subFlagSet.Changed("debug"))
+}
+
+func Test_PosixFlagSet_Local(t *testing.T) {
+	flagSet := NewPosixFlagSet()
+	flagSet.String("name", "", "")
+	flagSet.String("id", "", "")
+	flagSet.Bool("json", false, "")
+	flagSet.Bool("yaml", false, "")
+	flagSet.Local("id")
+	flagSet.Local("json")
+	flagSet.Required("id")
+	flagSet.AtMostOneOf("json", "yaml")
+
+	_, err := flagSet.Parse([]string{"--name", "bob", "--json"})
+	assert.Nil(t, err)
+
+	subFlagSet := flagSet.SubFlagSet("sub")
+	names := []string{}
+	subFlagSet.VisitAll(func(name string, value Value) {
+		names = append(names, name)
+	})
+	assert.Equal(t, []string{"name", "yaml"}, names)
+	assert.Nil(t, subFlagSet.Get("id"))
+	assert.False(t, subFlagSet.Changed("json"))
+	assert.True(t, subFlagSet.Changed("name"))
+
+	_, err = subFlagSet.Parse([]string{"--id", "1"})
+	assert.EqualError(t, err, "flagset parse failed flag provided but not defined: --id")
+
+	_, err = subFlagSet.Parse([]string{"--yaml"})
+	assert.Nil(t, err)
+	assert.EqualError(t, subFlagSet.Validate(), "flagset validation failed: --id is required, --yaml cannot be used with --json")
+
+	assert.Nil(t, flagSet.Set("id", "1"))
+	assert.EqualError(t, subFlagSet.SubFlagSet("leaf").Validate(), "flagset validation failed: --yaml cannot be used with --json")
+}
+
+func Test_PosixFlagSet_Snapshot(t *testing.T) {
+	flagSet := NewPosixFlagSet()
+	flagSet.String("name", "default", "")
+	flagSet.Reset()
+
+	flagSet.Snapshot()
+	tags := &StringArrayFlag{}
+	for i := 0; i < 2; i++ {
+		subFlagSet := flagSet.SubFlagSet("sub")
+		subFlagSet.Var(tags, "tag", "")
+		_, err := subFlagSet.Parse([]string{"--name", "bob", "--tag", "a"})
+		assert.Nil(t, err)
+
+		name, _ := subFlagSet.GetString("name")
+		assert.Equal(t, "bob", name)
+		assert.Equal(t, &StringArrayFlag{"a"}, tags)
+
+		flagSet.Reset()
+		name, _ = flagSet.GetString("name")
+		assert.Equal(t, "default", name)
+		assert.False(t, flagSet.Changed("name"))
+		assert.Equal(t, &StringArrayFlag{}, tags)
+	}
+}
+
+func Test_PosixFlagSet_Snapshot_Values(t *testing.T) {
+	flagSet := NewPosixFlagSet()
+	flagSet.StringMap("labels,l", map[string]string{"env": "dev"}, "")
+	flagSet.URL("endpoint", &url.URL{Scheme: "https", Host: "example.com"}, "")
+	flagSet.Snapshot()
+
+	_, err := flagSet.Parse([]string{"-l", "env=prod", "--labels", "tier=web"})
+	assert.Nil(t, err)
+	endpoint, _ := flagSet.GetURL("endpoint")
+	endpoint.Host = "modified.com"
+
+	flagSet.Reset()
+	labels, _ := flagSet.GetStringMap("labels")
+	assert.Equal(t, map[string]string{"env": "dev"}, labels)
+	endpoint, _ = flagSet.GetURL("endpoint")
+	assert.Equal(t, "https://example.com", endpoint.String())
+}
+
+func Test_PosixFlagSet_Alias(t *testing.T) {
+	flagSet := NewPosixFlagSet()
+	flagSet.String("output", "out.txt", "the output `file`")
+	flagSet.Alias("output", "o")
+	flagSet.DeprecatedAlias("output", "outfile", "use --output instead")
+	flagSet.Bool("verbose,v", false, "verbose output")
+
+	assert.Equal(t, "  --output, -o, --outfile file\n    \tthe output file (default \"out.txt\")\n  -v, --verbose\n    \tverbose output\n", flagSet.DefaultUsage())
+
+	names := []string{}
+	flagSet.VisitAll(func(name string, value Value) {
+		names = append(names, name)
+	})
+	assert.Equal(t, []string{"output", "verbose"}, names)
+
+	_, err := flagSet.Parse([]string{"-oa.txt"})
+	assert.Nil(t, err)
+	assert.Equal(t, "a.txt", flagSet.Get("output"))
+	assert.Equal(t, "a.txt", flagSet.Get("outfile"))
+	assert.True(t, flagSet.Changed("o"))
+	assert.Empty(t, flagSet.Warnings())
+
+	subFlagSet := flagSet.SubFlagSet("sub")
+	_, err = subFlagSet.Parse([]string{"--outfile", "b.txt"})
+	assert.Nil(t, err)
+	assert.Equal(t, "b.txt", subFlagSet.Get("output"))
+	assert.Equal(t, []string{"Flag \"--outfile\" is deprecated, use --output instead"}, subFlagSet.Warnings())
+
+	assert.PanicsWithValue(t, "flag redefined: v", func() {
+		flagSet.Alias("output", "v")
+	})
+	assert.PanicsWithValue(t, "flag \"missing\" is not defined", func() {
+		flagSet.Alias("missing", "m")
+	})
+}
+
+func Test_PosixFlagSet_HiddenAndDeprecated(t *testing.T) {
+	flagSet := NewPosixFlagSet()
+	flagSet.Bool("debug-trace", false, "trace output")
+	flagSet.Hidden("debug-trace")
+	flagSet.String("user,u", "", "the user name")
+	flagSet.Deprecated("u", "use --name instead")
+	flagSet.String("name", "", "the name")
+
+	assert.Equal(t, "  --name string\n    \tthe name\n  -u, --user string\n    \tthe user name\n", flagSet.DefaultUsage())
+
+	_, err := flagSet.Parse([]string{"--debug-trace", "--name", "bob"})
+	assert.Nil(t, err)
+	assert.Equal(t, true, flagSet.Get("debug-trace"))
+	assert.Empty(t, flagSet.Warnings())
+
+	_, err = flagSet.Parse([]string{"-u", "bob"})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"Flag \"-u\" is deprecated, use --name instead"}, flagSet.Warnings())
+}
+
+func Test_PosixFlagSet_Describe(t *testing.T) {
+	flagSet := NewPosixFlagSet()
+	flagSet.Bool("verbose,v", false, "verbose output")
+	flagSet.Bool("q", false, "quiet output")
+	flagSet.Deprecated("q", "use --verbose=false instead")
+
+	subFlagSet := flagSet.SubFlagSet("users")
+	subFlagSet.Duration("timeout", time.Second, "the request timeout")
+
+	descriptors := subFlagSet.Describe()
+	assert.Len(t, descriptors, 3)
+	assert.Equal(t, "q", descriptors[0].Name)
+	assert.Equal(t, "", descriptors[0].Shorthand)
+	assert.Equal(t, "use --verbose=false instead", descriptors[0].Deprecated)
+	assert.Equal(t, "timeout", descriptors[1].Name)
+	assert.Equal(t, "duration", descriptors[1].Type)
+	assert.Equal(t, "1s", descriptors[1].Default)
+	assert.Equal(t, []string{"users"}, descriptors[1].Path)
+	assert.Equal(t, "verbose", descriptors[2].Name)
+	assert.Equal(t, "v", descriptors[2].Shorthand)
+	assert.Empty(t, descriptors[2].Path)
+}
+
+func Test_PosixFlagSet_FileValue(t *testing.T) {
+	passwordPath := filepath.Join(t.TempDir(), "password")
+	assert.Nil(t, os.WriteFile(passwordPath, []byte("s3cret\n"), 0600))
+
+	flagSet := NewPosixFlagSet()
+	flagSet.String("password,p", "", "")
+	flagSet.String("body", "", "")
+	flagSet.String("name", "", "")
+	flagSet.FileValue("p")
+	flagSet.FileValue("body")
+	flagSet.SetInput(strings.NewReader("{}\n"))
+
+	_, err := flagSet.Parse([]string{"-p@" + passwordPath, "--body", "@-", "--name=@bob"})
+	assert.Nil(t, err)
+	password, _ := flagSet.GetString("password")
+	assert.Equal(t, "s3cret", password)
+	body, _ := flagSet.GetString("body")
+	assert.Equal(t, "{}", body)
+	name, _ := flagSet.GetString("name")
+	assert.Equal(t, "@bob", name)
+
+	_, err = flagSet.Parse([]string{"--password=@" + passwordPath + ".missing"})
+	assert.EqualError(t, err, "flagset parse failed failed to read value for flag --password: open "+passwordPath+".missing: no such file or directory")
+}
+
+func Test_PosixFlagSet_Secret(t *testing.T) {
+	flagSet := NewPosixFlagSet()
+	flagSet.Secret("password,p", "the account password")
+
+	_, err := flagSet.Parse([]string{"-p", "s3cret"})
+	assert.Nil(t, err)
+	password, _ := flagSet.GetString("password")
+	assert.Equal(t, "s3cret", password)
+	assert.Equal(t, "  -p, --password secret\n    \tthe account password\n", flagSet.DefaultUsage())
+
+	values := []string{}
+	flagSet.VisitAll(func(name string, value Value) {
+		values = append(values, value.String())
+	})
+	assert.Equal(t, []string{RedactedValue}, values)
+}
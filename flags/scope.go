@@ -0,0 +1,53 @@
+package flags
+
+// localScope contains the local flags of a parent flagset, which are not inherited by a
+// sub flagset but are still validated when the sub flagset is validated.
+type localScope struct {
+	metadata map[string]*flagMeta
+	groups   []*flagGroup
+	get      func(name string) interface{}
+}
+
+// newLocalScope returns the local scope of the flagset, or nil if the flagset does
+// not contain any local flags, and the groups that should be inherited by a sub flagset.
+func newLocalScope(metadata map[string]*flagMeta, groups []*flagGroup, get func(name string) interface{}) (*localScope, []*flagGroup) {
+	hasLocal := false
+	for _, meta := range metadata {
+		if meta.local {
+			hasLocal = true
+			break
+		}
+	}
+	if !hasLocal {
+		return nil, append([]*flagGroup{}, groups...)
+	}
+
+	scope := &localScope{
+		metadata: make(map[string]*flagMeta, len(metadata)),
+		get:      get,
+	}
+	for name, meta := range metadata {
+		scope.metadata[name] = meta
+	}
+
+	// groups that contain a local flag cannot be inherited,
+	// so they are validated as part of the local scope
+	inherited := []*flagGroup{}
+	for _, group := range groups {
+		if group.hasLocal(metadata) {
+			scope.groups = append(scope.groups, group)
+		} else {
+			inherited = append(inherited, group)
+		}
+	}
+	return scope, inherited
+}
+
+// subScopes returns the local scopes that will be validated by a sub flagset.
+func subScopes(scopes []*localScope, scope *localScope) []*localScope {
+	subScopes := append([]*localScope{}, scopes...)
+	if scope != nil {
+		subScopes = append(subScopes, scope)
+	}
+	return subScopes
+}
@@ -0,0 +1,51 @@
+package flags
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_newLocalScope(t *testing.T) {
+	get := func(name string) interface{} {
+		return name
+	}
+
+	t.Run("no local flags", func(t *testing.T) {
+		groups := []*flagGroup{newFlagGroup(allOrNone, []string{"a", "b"})}
+		scope, inherited := newLocalScope(map[string]*flagMeta{
+			"a": {},
+			"b": {},
+		}, groups, get)
+		assert.Nil(t, scope)
+		assert.Equal(t, groups, inherited)
+	})
+
+	t.Run("local flags", func(t *testing.T) {
+		persistent := newFlagGroup(allOrNone, []string{"a", "b"})
+		local := newFlagGroup(atMostOneOf, []string{"b", "c"})
+		metadata := map[string]*flagMeta{
+			"a": {},
+			"b": {},
+			"c": {local: true},
+		}
+		scope, inherited := newLocalScope(metadata, []*flagGroup{persistent, local}, get)
+		assert.Equal(t, []*flagGroup{persistent}, inherited)
+		assert.Equal(t, metadata, scope.metadata)
+		assert.Equal(t, []*flagGroup{local}, scope.groups)
+		assert.Equal(t, "c", scope.get("c"))
+	})
+}
+
+func Test_subScopes(t *testing.T) {
+	first := &localScope{}
+	second := &localScope{}
+
+	assert.Equal(t, []*localScope{}, subScopes(nil, nil))
+	assert.Equal(t, []*localScope{first}, subScopes(nil, first))
+
+	scopes := []*localScope{first}
+	actual := subScopes(scopes, second)
+	assert.Equal(t, []*localScope{first, second}, actual)
+	assert.Len(t, scopes, 1)
+}
@@ -0,0 +1,37 @@
+package flags
+
+// RedactedValue is displayed in place of the value of a secret flag.
+const RedactedValue = "********"
+
+// SecretValue is implemented by flag values that should never be displayed, such as passwords.
+// The String function returns a redacted value, while the Get function returns the secret.
+type SecretValue interface {
+	Value
+	// IsSecret returns true if the value should not be displayed.
+	IsSecret() bool
+}
+
+type secretValue string
+
+func (value *secretValue) Set(s string) error {
+	*value = secretValue(s)
+	return nil
+}
+
+func (value *secretValue) Get() interface{} { return string(*value) }
+
+// String returns the redacted value, or an empty string if the secret has not been set.
+func (value *secretValue) String() string {
+	if value == nil || *value == "" {
+		return ""
+	}
+	return RedactedValue
+}
+
+func (value *secretValue) IsSecret() bool { return true }
+
+// isSecret returns true if the flag value is a secret.
+func isSecret(value interface{}) bool {
+	secret, ok := value.(SecretValue)
+	return ok && secret.IsSecret()
+}
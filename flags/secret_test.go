@@ -0,0 +1,21 @@
+package flags
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_secretValue(t *testing.T) {
+	value := new(secretValue)
+	assert.Equal(t, "", value.String())
+	assert.Equal(t, "", value.Get())
+	assert.True(t, value.IsSecret())
+
+	assert.Nil(t, value.Set("s3cret"))
+	assert.Equal(t, RedactedValue, value.String())
+	assert.Equal(t, "s3cret", value.Get())
+
+	assert.True(t, isSecret(value))
+	assert.False(t, isSecret(newStringValue("")))
+}
@@ -0,0 +1,79 @@
+package flags
+
+// resetter is implemented by the flag values in this package that cannot be restored by
+// calling Set with their default value, such as the values that append to their value each
+// time they are set, and the values where an empty default value cannot be parsed.
+type resetter interface {
+	// reset restores the value to the default value, as formatted when the flag was defined.
+	reset(defValue string) error
+}
+
+// flagState is the saved state of a flag value and its metadata.
+type flagState struct {
+	value     Value
+	defValue  string
+	meta      *flagMeta
+	source    Source
+	configKey string
+}
+
+// newFlagState saves the state of the flag metadata, the flag value is
+// restored to its default value rather than the value at the time it was saved.
+func newFlagState(f *definedFlag, meta *flagMeta) *flagState {
+	return &flagState{
+		value:     f.value,
+		defValue:  f.defValue,
+		meta:      meta,
+		source:    meta.source,
+		configKey: meta.configKey,
+	}
+}
+
+// restore restores the flag value to its default value, and its metadata to the saved state.
+//
+// Values defined outside of this package are restored by calling Set with their default value,
+// so a value that appends to its value each time it is set will not be restored.
+func (state *flagState) restore() {
+	// the error is ignored as the default value was valid when the flag was defined
+	if value, ok := state.value.(resetter); ok {
+		value.reset(state.defValue)
+	} else {
+		state.value.Set(state.defValue)
+	}
+	state.meta.source = state.source
+	state.meta.configKey = state.configKey
+}
+
+// snapshot contains the saved state of the flags in a flagset, and of the flags
+// defined by any sub flagsets after the snapshot was taken.
+//
+// The snapshot is shared with sub flagsets so that flags defined at any level
+// can be restored, such as between the commands of an interactive shell.
+type snapshot struct {
+	flags   []*flagState
+	defined []*flagState
+}
+
+// record saves the state of a flag defined after the snapshot was taken.
+func (snapshot *snapshot) record(f *definedFlag, meta *flagMeta) {
+	if snapshot == nil {
+		return
+	}
+	snapshot.defined = append(snapshot.defined, newFlagState(f, meta))
+}
+
+// restore restores every flag to its saved state, and forgets the flags defined after the snapshot was taken.
+func (snapshot *snapshot) restore() {
+	if snapshot == nil {
+		return
+	}
+	// the flags are restored in reverse order so a value defined more
+	// than once is restored to the state it was first saved with
+	for i := len(snapshot.defined) - 1; i >= 0; i-- {
+		snapshot.defined[i].restore()
+	}
+	for i := len(snapshot.flags) - 1; i >= 0; i-- {
+		snapshot.flags[i].restore()
+	}
+	snapshot.defined = nil
+}
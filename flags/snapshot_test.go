@@ -0,0 +1,139 @@
+package flags
+
+import (
+	"net"
+	"net/url"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_flagState(t *testing.T) {
+
+	t.Run("value", func(t *testing.T) {
+		value := newIntValue(1)
+		meta := &flagMeta{source: SourceConfig, configKey: "count"}
+		state := newFlagState(&definedFlag{value: value, defValue: value.String()}, meta)
+
+		assert.Nil(t, value.Set("2"))
+		meta.source = SourceCommandLine
+		meta.configKey = ""
+
+		state.restore()
+		assert.Equal(t, int64(1), value.Get())
+		assert.Equal(t, &flagMeta{source: SourceConfig, configKey: "count"}, meta)
+	})
+
+	t.Run("resetter", func(t *testing.T) {
+		value := newStringMapValue(map[string]string{"a": "1"})
+		state := newFlagState(&definedFlag{value: value, defValue: value.String()}, &flagMeta{})
+
+		assert.Nil(t, value.Set("b=2"))
+		assert.Nil(t, value.Set("c=3"))
+		state.restore()
+		assert.Equal(t, map[string]string{"a": "1"}, value.Get())
+
+		assert.Nil(t, value.Set("d=4"))
+		assert.Equal(t, map[string]string{"d": "4"}, value.Get())
+	})
+
+	t.Run("custom value", func(t *testing.T) {
+		target := "one"
+		value := &pointerValue{P: &target}
+		state := newFlagState(&definedFlag{value: value, defValue: value.String()}, &flagMeta{})
+
+		assert.Nil(t, value.Set("two"))
+		state.restore()
+		assert.Equal(t, "one", target)
+
+		assert.Nil(t, value.Set("two"))
+		assert.Equal(t, "two", target)
+	})
+}
+
+func Test_snapshot(t *testing.T) {
+	var nilSnapshot *snapshot
+	nilSnapshot.record(&definedFlag{value: newIntValue(0)}, &flagMeta{})
+	nilSnapshot.restore()
+
+	base := newIntValue(1)
+	defined := newIntValue(2)
+	snapshot := &snapshot{
+		flags: []*flagState{newFlagState(&definedFlag{value: base, defValue: "1"}, &flagMeta{})},
+	}
+	snapshot.record(&definedFlag{value: defined, defValue: "2"}, &flagMeta{})
+	assert.Nil(t, defined.Set("3"))
+	snapshot.record(&definedFlag{value: defined, defValue: "3"}, &flagMeta{})
+	assert.Nil(t, base.Set("4"))
+	assert.Nil(t, defined.Set("5"))
+
+	snapshot.restore()
+	assert.Equal(t, int64(1), base.Get())
+	assert.Equal(t, int64(2), defined.Get())
+	assert.Nil(t, snapshot.defined)
+	assert.Len(t, snapshot.flags, 1)
+}
+
+func Test_resetter(t *testing.T) {
+	tests := []struct {
+		name  string
+		value Value
+		input string
+	}{
+		{name: "int slice", value: newIntSliceValue([]int64{1, 2}), input: "3"},
+		{name: "empty int slice", value: newIntSliceValue(nil), input: "3"},
+		{name: "uint slice", value: newUintSliceValue([]uint64{1}), input: "3"},
+		{name: "float slice", value: newFloatSliceValue([]float64{0.5}), input: "1.5"},
+		{name: "string map", value: newStringMapValue(map[string]string{"a": "1", "b": "2"}), input: "c=3"},
+		{name: "string array", value: &StringArrayFlag{"a", "b"}, input: "c"},
+		{name: "enum", value: newEnumValue("", []string{"json", "text"}), input: "json"},
+		{name: "time", value: newTimeValue(time.Time{}, time.RFC3339), input: "2021-03-04T05:06:07Z"},
+		{name: "time default", value: newTimeValue(time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC), time.RFC3339), input: "2021-03-04T05:06:07Z"},
+		{name: "ip", value: newIPValue(nil), input: "10.0.0.1"},
+		{name: "ip net", value: newIPNetValue(net.IPNet{}), input: "10.0.0.0/8"},
+		{name: "url", value: newURLValue(nil), input: "https://example.com"},
+		{name: "url default", value: newURLValue(&url.URL{Scheme: "https", Host: "example.com"}), input: "https://other.com"},
+		{name: "regexp", value: newRegexpValue(nil), input: "^a+$"},
+		{name: "regexp default", value: newRegexpValue(regexp.MustCompile("^b+$")), input: "^a+$"},
+		{name: "path", value: newPathValue("", PathFile), input: "snapshot_test.go"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			defValue := test.value.String()
+			expected := test.value.Get()
+
+			assert.Nil(t, test.value.Set(test.input))
+			set := test.value.String()
+			assert.Nil(t, test.value.Set(test.input))
+			assert.Nil(t, test.value.(resetter).reset(defValue))
+			assert.Equal(t, expected, test.value.Get())
+			assert.Equal(t, defValue, test.value.String())
+
+			// the value is set as if it had not been set before it was reset
+			assert.Nil(t, test.value.Set(test.input))
+			assert.Equal(t, set, test.value.String())
+		})
+	}
+}
+
+// pointerValue is a Value that sets the string it points to, as a value defined outside of this package might.
+type pointerValue struct {
+	P *string
+}
+
+func (value *pointerValue) String() string {
+	if value.P == nil {
+		return ""
+	}
+	return *value.P
+}
+
+func (value *pointerValue) Set(s string) error {
+	*value.P = s
+	return nil
+}
+
+func (value *pointerValue) Get() interface{} { return *value.P }
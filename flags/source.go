@@ -0,0 +1,33 @@
+package flags
+
+// Source describes where the value of a flag was set from.
+//
+// Sources are ordered by precedence, so a flag value will only be
+// replaced by a value from a source with a higher precedence.
+type Source int
+
+const (
+	// SourceDefault indicates the flag is using the default value.
+	SourceDefault Source = iota
+	// SourceConfig indicates the flag value was set from a configuration source.
+	SourceConfig
+	// SourceEnvironment indicates the flag value was set from an environment variable.
+	SourceEnvironment
+	// SourceCommandLine indicates the flag value was set from the command-line, or by using Set.
+	SourceCommandLine
+)
+
+// String returns the name of the flag source.
+func (source Source) String() string {
+	switch source {
+	case SourceDefault:
+		return "default"
+	case SourceConfig:
+		return "config"
+	case SourceEnvironment:
+		return "environment"
+	case SourceCommandLine:
+		return "command-line"
+	}
+	return "unknown"
+}
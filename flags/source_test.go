@@ -0,0 +1,15 @@
+package flags
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Source_String(t *testing.T) {
+	assert.Equal(t, "default", SourceDefault.String())
+	assert.Equal(t, "environment", SourceEnvironment.String())
+	assert.Equal(t, "command-line", SourceCommandLine.String())
+	assert.Equal(t, "config", SourceConfig.String())
+	assert.Equal(t, "unknown", Source(-1).String())
+}
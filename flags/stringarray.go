@@ -22,6 +22,15 @@ func (flag *StringArrayFlag) Set(value string) error {
 	return nil
 }
 
+// reset clears the string array before setting the default value, as Set appends to the array.
+func (flag *StringArrayFlag) reset(defValue string) error {
+	*flag = StringArrayFlag{}
+	if defValue == "" {
+		return nil
+	}
+	return flag.Set(defValue)
+}
+
 // Get returns the string array value.
 func (flag *StringArrayFlag) Get() interface{} {
 	return []string(*flag)
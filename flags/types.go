@@ -0,0 +1,407 @@
+package flags
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"net/url"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type stringMapValue struct {
+	sliceValue
+	values map[string]string
+}
+
+func newStringMapValue(defaultValue map[string]string) *stringMapValue {
+	values := make(map[string]string, len(defaultValue))
+	for key, value := range defaultValue {
+		values[key] = value
+	}
+	return &stringMapValue{values: values}
+}
+
+func (value *stringMapValue) Set(s string) error {
+	// the values are copied so they are not modified if the value fails to parse
+	parsed := make(map[string]string)
+	if value.changed {
+		for key, item := range value.values {
+			parsed[key] = item
+		}
+	}
+	err := value.split(s, func(item string) error {
+		index := strings.Index(item, "=")
+		if index <= 0 {
+			return errParse
+		}
+		parsed[strings.TrimSpace(item[:index])] = strings.TrimSpace(item[index+1:])
+		return nil
+	})
+	if err == nil {
+		value.values = parsed
+	}
+	return err
+}
+
+func (value *stringMapValue) reset(defValue string) error {
+	value.values = make(map[string]string)
+	return value.restore(defValue, value.Set)
+}
+
+func (value *stringMapValue) Get() interface{} {
+	values := make(map[string]string, len(value.values))
+	for key, item := range value.values {
+		values[key] = item
+	}
+	return values
+}
+
+func (value *stringMapValue) String() string {
+	keys := make([]string, 0, len(value.values))
+	for key := range value.values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	items := make([]string, len(keys))
+	for i, key := range keys {
+		items[i] = fmt.Sprintf("%s=%s", key, value.values[key])
+	}
+	return strings.Join(items, ",")
+}
+
+type timeValue struct {
+	value  time.Time
+	layout string
+}
+
+func newTimeValue(defaultValue time.Time, layout string) *timeValue {
+	return &timeValue{value: defaultValue, layout: layout}
+}
+
+func (value *timeValue) Set(s string) error {
+	parsed, err := time.Parse(value.layout, s)
+	if err != nil {
+		return errParse
+	}
+	value.value = parsed
+	return nil
+}
+
+func (value *timeValue) reset(defValue string) error {
+	if defValue == "" {
+		value.value = time.Time{}
+		return nil
+	}
+	return value.Set(defValue)
+}
+
+func (value *timeValue) Get() interface{} { return value.value }
+
+func (value *timeValue) String() string {
+	if value.value.IsZero() {
+		return ""
+	}
+	return value.value.Format(value.layout)
+}
+
+type ipValue net.IP
+
+func newIPValue(defaultValue net.IP) *ipValue {
+	value := ipValue(defaultValue)
+	return &value
+}
+
+func (value *ipValue) Set(s string) error {
+	parsed := net.ParseIP(strings.TrimSpace(s))
+	if parsed == nil {
+		return errParse
+	}
+	*value = ipValue(parsed)
+	return nil
+}
+
+func (value *ipValue) reset(defValue string) error {
+	if defValue == "" {
+		*value = nil
+		return nil
+	}
+	return value.Set(defValue)
+}
+
+func (value *ipValue) Get() interface{} { return net.IP(*value) }
+
+func (value *ipValue) String() string {
+	if len(*value) == 0 {
+		return ""
+	}
+	return net.IP(*value).String()
+}
+
+type ipNetValue net.IPNet
+
+func newIPNetValue(defaultValue net.IPNet) *ipNetValue {
+	value := ipNetValue(defaultValue)
+	return &value
+}
+
+func (value *ipNetValue) Set(s string) error {
+	_, parsed, err := net.ParseCIDR(strings.TrimSpace(s))
+	if err != nil {
+		return errParse
+	}
+	*value = ipNetValue(*parsed)
+	return nil
+}
+
+func (value *ipNetValue) reset(defValue string) error {
+	if defValue == "" {
+		*value = ipNetValue{}
+		return nil
+	}
+	return value.Set(defValue)
+}
+
+func (value *ipNetValue) Get() interface{} { return net.IPNet(*value) }
+
+func (value *ipNetValue) String() string {
+	if len(value.IP) == 0 {
+		return ""
+	}
+	ipNet := net.IPNet(*value)
+	return ipNet.String()
+}
+
+type urlValue struct {
+	value *url.URL
+}
+
+func newURLValue(defaultValue *url.URL) *urlValue {
+	return &urlValue{value: defaultValue}
+}
+
+func (value *urlValue) Set(s string) error {
+	parsed, err := url.Parse(s)
+	if err != nil {
+		return errParse
+	}
+	value.value = parsed
+	return nil
+}
+
+func (value *urlValue) reset(defValue string) error {
+	if defValue == "" {
+		value.value = nil
+		return nil
+	}
+	return value.Set(defValue)
+}
+
+func (value *urlValue) Get() interface{} { return value.value }
+
+func (value *urlValue) String() string {
+	if value.value == nil {
+		return ""
+	}
+	return value.value.String()
+}
+
+// byteSizeUnits contains the byte size unit multipliers, where the unit
+// suffixes are case insensitive and the i suffix denotes a binary unit.
+var byteSizeUnits = map[string]uint64{
+	"":    1,
+	"b":   1,
+	"k":   1000,
+	"kb":  1000,
+	"ki":  1 << 10,
+	"kib": 1 << 10,
+	"m":   1000 * 1000,
+	"mb":  1000 * 1000,
+	"mi":  1 << 20,
+	"mib": 1 << 20,
+	"g":   1000 * 1000 * 1000,
+	"gb":  1000 * 1000 * 1000,
+	"gi":  1 << 30,
+	"gib": 1 << 30,
+	"t":   1000 * 1000 * 1000 * 1000,
+	"tb":  1000 * 1000 * 1000 * 1000,
+	"ti":  1 << 40,
+	"tib": 1 << 40,
+	"p":   1000 * 1000 * 1000 * 1000 * 1000,
+	"pb":  1000 * 1000 * 1000 * 1000 * 1000,
+	"pi":  1 << 50,
+	"pib": 1 << 50,
+}
+
+// byteSizeFormats contains the units used to format a byte size, largest first.
+var byteSizeFormats = []struct {
+	unit string
+	size uint64
+}{
+	{unit: "PiB", size: 1 << 50},
+	{unit: "PB", size: 1000 * 1000 * 1000 * 1000 * 1000},
+	{unit: "TiB", size: 1 << 40},
+	{unit: "TB", size: 1000 * 1000 * 1000 * 1000},
+	{unit: "GiB", size: 1 << 30},
+	{unit: "GB", size: 1000 * 1000 * 1000},
+	{unit: "MiB", size: 1 << 20},
+	{unit: "MB", size: 1000 * 1000},
+	{unit: "KiB", size: 1 << 10},
+	{unit: "KB", size: 1000},
+}
+
+// parseByteSize parses a byte size, such as 512, 1.5GB, or 10MiB.
+func parseByteSize(s string) (uint64, error) {
+	s = strings.TrimSpace(s)
+	index := strings.IndexFunc(s, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.'
+	})
+	if index < 0 {
+		index = len(s)
+	}
+
+	multiplier, ok := byteSizeUnits[strings.ToLower(strings.TrimSpace(s[index:]))]
+	if !ok || index == 0 {
+		return 0, errParse
+	}
+	number, err := strconv.ParseFloat(s[:index], 64)
+	if err != nil {
+		return 0, numError(err)
+	}
+	size := number * float64(multiplier)
+	if size >= math.MaxUint64 {
+		return 0, errRange
+	}
+	return uint64(size), nil
+}
+
+// formatByteSize formats the byte size using the largest unit that it is a multiple of.
+func formatByteSize(size uint64) string {
+	if size == 0 {
+		return "0"
+	}
+	for _, format := range byteSizeFormats {
+		if size%format.size == 0 {
+			return fmt.Sprintf("%d%s", size/format.size, format.unit)
+		}
+	}
+	return fmt.Sprintf("%dB", size)
+}
+
+type byteSizeValue uint64
+
+func newByteSizeValue(defaultValue uint64) *byteSizeValue {
+	value := byteSizeValue(defaultValue)
+	return &value
+}
+
+func (value *byteSizeValue) Set(s string) error {
+	parsed, err := parseByteSize(s)
+	if err != nil {
+		return err
+	}
+	*value = byteSizeValue(parsed)
+	return nil
+}
+
+func (value *byteSizeValue) Get() interface{} { return uint64(*value) }
+
+func (value *byteSizeValue) String() string { return formatByteSize(uint64(*value)) }
+
+type regexpValue struct {
+	value *regexp.Regexp
+}
+
+func newRegexpValue(defaultValue *regexp.Regexp) *regexpValue {
+	return &regexpValue{value: defaultValue}
+}
+
+func (value *regexpValue) Set(s string) error {
+	parsed, err := regexp.Compile(s)
+	if err != nil {
+		return err
+	}
+	value.value = parsed
+	return nil
+}
+
+func (value *regexpValue) reset(defValue string) error {
+	if defValue == "" {
+		value.value = nil
+		return nil
+	}
+	return value.Set(defValue)
+}
+
+func (value *regexpValue) Get() interface{} { return value.value }
+
+func (value *regexpValue) String() string {
+	if value.value == nil {
+		return ""
+	}
+	return value.value.String()
+}
+
+// PathCheck describes the existence check performed when a path flag is set.
+type PathCheck int
+
+const (
+	// PathAny allows any path, whether it exists or not.
+	PathAny PathCheck = iota
+	// PathExists requires the path to exist.
+	PathExists
+	// PathFile requires the path to exist and be a file.
+	PathFile
+	// PathDir requires the path to exist and be a directory.
+	PathDir
+)
+
+// check returns an error if the path does not pass the existence check.
+func (check PathCheck) check(path string) error {
+	if check == PathAny {
+		return nil
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("path does not exist")
+	}
+	if check == PathFile && info.IsDir() {
+		return fmt.Errorf("path is not a file")
+	}
+	if check == PathDir && !info.IsDir() {
+		return fmt.Errorf("path is not a directory")
+	}
+	return nil
+}
+
+type pathValue struct {
+	value string
+	check PathCheck
+}
+
+func newPathValue(defaultValue string, check PathCheck) *pathValue {
+	return &pathValue{value: defaultValue, check: check}
+}
+
+func (value *pathValue) Set(s string) error {
+	if err := value.check.check(s); err != nil {
+		return err
+	}
+	value.value = s
+	return nil
+}
+
+// reset sets the default value without checking the path, as it was not checked when the flag was defined.
+func (value *pathValue) reset(defValue string) error {
+	value.value = defValue
+	return nil
+}
+
+func (value *pathValue) Get() interface{} { return value.value }
+
+func (value *pathValue) String() string { return value.value }
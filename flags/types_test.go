@@ -0,0 +1,222 @@
+package flags
+
+import (
+	"flag"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Validate the value types match the standard golang flag library Getter interface
+var _ flag.Getter = newStringMapValue(nil)
+var _ flag.Getter = newTimeValue(time.Time{}, time.RFC3339)
+var _ flag.Getter = newIPValue(nil)
+var _ flag.Getter = newIPNetValue(net.IPNet{})
+var _ flag.Getter = newURLValue(nil)
+var _ flag.Getter = newByteSizeValue(0)
+var _ flag.Getter = newRegexpValue(nil)
+var _ flag.Getter = newPathValue("", PathAny)
+
+func Test_Types(t *testing.T) {
+
+	type expected struct {
+		err    error
+		value  interface{}
+		string string
+	}
+
+	_, localNet, _ := net.ParseCIDR("192.168.0.0/16")
+	exampleURL, _ := url.Parse("https://example.com/path?q=1")
+
+	tests := []struct {
+		name     string
+		value    Value
+		input    string
+		expected expected
+	}{
+		{
+			name:     "string map",
+			value:    newStringMapValue(map[string]string{"a": "1"}),
+			input:    "b=2, c = 3",
+			expected: expected{value: map[string]string{"b": "2", "c": "3"}, string: "b=2,c=3"},
+		},
+		{
+			name:     "string map invalid",
+			value:    newStringMapValue(map[string]string{"a": "1"}),
+			input:    "b",
+			expected: expected{err: errParse, value: map[string]string{"a": "1"}, string: "a=1"},
+		},
+		{
+			name:     "time",
+			value:    newTimeValue(time.Time{}, "2006-01-02"),
+			input:    "2021-03-04",
+			expected: expected{value: time.Date(2021, 3, 4, 0, 0, 0, 0, time.UTC), string: "2021-03-04"},
+		},
+		{
+			name:     "time invalid",
+			value:    newTimeValue(time.Time{}, "2006-01-02"),
+			input:    "yesterday",
+			expected: expected{err: errParse, value: time.Time{}, string: ""},
+		},
+		{
+			name:     "ip",
+			value:    newIPValue(nil),
+			input:    "10.0.0.1",
+			expected: expected{value: net.ParseIP("10.0.0.1"), string: "10.0.0.1"},
+		},
+		{
+			name:     "ip invalid",
+			value:    newIPValue(net.ParseIP("::1")),
+			input:    "10.0.0",
+			expected: expected{err: errParse, value: net.ParseIP("::1"), string: "::1"},
+		},
+		{
+			name:     "ipnet",
+			value:    newIPNetValue(net.IPNet{}),
+			input:    "192.168.1.1/16",
+			expected: expected{value: *localNet, string: "192.168.0.0/16"},
+		},
+		{
+			name:     "ipnet invalid",
+			value:    newIPNetValue(net.IPNet{}),
+			input:    "192.168.1.1",
+			expected: expected{err: errParse, value: net.IPNet{}, string: ""},
+		},
+		{
+			name:     "url",
+			value:    newURLValue(nil),
+			input:    "https://example.com/path?q=1",
+			expected: expected{value: exampleURL, string: "https://example.com/path?q=1"},
+		},
+		{
+			name:     "url invalid",
+			value:    newURLValue(nil),
+			input:    "http://[::1",
+			expected: expected{err: errParse, value: (*url.URL)(nil), string: ""},
+		},
+		{
+			name:     "byte size",
+			value:    newByteSizeValue(0),
+			input:    "10MiB",
+			expected: expected{value: uint64(10 << 20), string: "10MiB"},
+		},
+		{
+			name:     "byte size decimal",
+			value:    newByteSizeValue(0),
+			input:    "1.5 gb",
+			expected: expected{value: uint64(1500 * 1000 * 1000), string: "1500MB"},
+		},
+		{
+			name:     "byte size bytes",
+			value:    newByteSizeValue(0),
+			input:    "1023",
+			expected: expected{value: uint64(1023), string: "1023B"},
+		},
+		{
+			name:     "byte size invalid unit",
+			value:    newByteSizeValue(1024),
+			input:    "10XB",
+			expected: expected{err: errParse, value: uint64(1024), string: "1KiB"},
+		},
+		{
+			name:     "byte size invalid number",
+			value:    newByteSizeValue(0),
+			input:    "MB",
+			expected: expected{err: errParse, value: uint64(0), string: "0"},
+		},
+		{
+			name:     "byte size range",
+			value:    newByteSizeValue(0),
+			input:    "99999999PiB",
+			expected: expected{err: errRange, value: uint64(0), string: "0"},
+		},
+		{
+			name:     "path",
+			value:    newPathValue("", PathAny),
+			input:    "missing/file.txt",
+			expected: expected{value: "missing/file.txt", string: "missing/file.txt"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.value.Set(test.input)
+			assert.Equal(t, test.expected.err, err)
+			assert.Equal(t, test.expected.value, test.value.Get())
+			assert.Equal(t, test.expected.string, test.value.String())
+		})
+	}
+}
+
+func Test_stringMapValue_Append(t *testing.T) {
+	value := newStringMapValue(map[string]string{"a": "1"})
+	assert.Nil(t, value.Set("b=2"))
+	assert.Nil(t, value.Set("c=3,b=4"))
+	assert.Equal(t, map[string]string{"b": "4", "c": "3"}, value.Get())
+}
+
+func Test_regexpValue(t *testing.T) {
+	value := newRegexpValue(regexp.MustCompile("^a"))
+	assert.Equal(t, "^a", value.String())
+
+	assert.Nil(t, value.Set("^b+$"))
+	assert.Equal(t, regexp.MustCompile("^b+$"), value.Get())
+	assert.Equal(t, "^b+$", value.String())
+
+	assert.NotNil(t, value.Set("(unclosed"))
+	assert.Equal(t, "^b+$", value.String())
+
+	assert.Equal(t, "", newRegexpValue(nil).String())
+}
+
+func Test_pathValue(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "file.txt")
+	assert.Nil(t, os.WriteFile(file, []byte("test"), 0600))
+	missing := filepath.Join(dir, "missing")
+
+	tests := []struct {
+		name     string
+		check    PathCheck
+		input    string
+		expected string
+	}{
+		{name: "any missing", check: PathAny, input: missing},
+		{name: "exists file", check: PathExists, input: file},
+		{name: "exists dir", check: PathExists, input: dir},
+		{name: "exists missing", check: PathExists, input: missing, expected: "path does not exist"},
+		{name: "file", check: PathFile, input: file},
+		{name: "file dir", check: PathFile, input: dir, expected: "path is not a file"},
+		{name: "file missing", check: PathFile, input: missing, expected: "path does not exist"},
+		{name: "dir", check: PathDir, input: dir},
+		{name: "dir file", check: PathDir, input: file, expected: "path is not a directory"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			value := newPathValue("default", test.check)
+			err := value.Set(test.input)
+			if test.expected == "" {
+				assert.Nil(t, err)
+				assert.Equal(t, test.input, value.Get())
+			} else {
+				assert.EqualError(t, err, test.expected)
+				assert.Equal(t, "default", value.Get())
+			}
+		})
+	}
+}
+
+func Test_formatByteSize(t *testing.T) {
+	assert.Equal(t, "0", formatByteSize(0))
+	assert.Equal(t, "1KB", formatByteSize(1000))
+	assert.Equal(t, "2GiB", formatByteSize(2<<30))
+	assert.Equal(t, "3PB", formatByteSize(3*1000*1000*1000*1000*1000))
+	assert.Equal(t, "1001B", formatByteSize(1001))
+}
@@ -0,0 +1,153 @@
+package flags
+
+import (
+	goerrors "errors"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/evilmonkeyinc/golang-cli/errors"
+)
+
+var (
+	// errRequired is the validation failure used when a required flag has not been set.
+	errRequired = goerrors.New("is required")
+)
+
+// Validator is used to validate the value of a flag.
+type Validator func(value interface{}) error
+
+// Min returns a Validator that will ensure a numeric flag value is at least the specified value.
+func Min(min float64) Validator {
+	return func(value interface{}) error {
+		number, ok := toFloat(value)
+		if !ok {
+			return fmt.Errorf("must be a number")
+		}
+		if number < min {
+			return fmt.Errorf("must be at least %v", min)
+		}
+		return nil
+	}
+}
+
+// Max returns a Validator that will ensure a numeric flag value is at most the specified value.
+func Max(max float64) Validator {
+	return func(value interface{}) error {
+		number, ok := toFloat(value)
+		if !ok {
+			return fmt.Errorf("must be a number")
+		}
+		if number > max {
+			return fmt.Errorf("must be at most %v", max)
+		}
+		return nil
+	}
+}
+
+// Pattern returns a Validator that will ensure a string flag value matches the specified regular expression.
+//
+// Pattern will panic if the regular expression cannot be compiled.
+func Pattern(pattern string) Validator {
+	expression := regexp.MustCompile(pattern)
+	return func(value interface{}) error {
+		str, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("must be a string")
+		}
+		if !expression.MatchString(str) {
+			return fmt.Errorf("must match %q", pattern)
+		}
+		return nil
+	}
+}
+
+// toFloat converts numeric flag values to a float64.
+func toFloat(value interface{}) (float64, bool) {
+	switch number := value.(type) {
+	case int64:
+		return float64(number), true
+	case uint64:
+		return float64(number), true
+	case float64:
+		return number, true
+	case time.Duration:
+		return float64(number), true
+	}
+	return 0, false
+}
+
+// validate returns the first validation failure for the flag value.
+//
+// The validators are only evaluated if the flag has been set.
+func (meta *flagMeta) validate(value interface{}) error {
+	if !meta.isSet() {
+		if meta.required {
+			return errRequired
+		}
+		return nil
+	}
+	for _, validator := range meta.validators {
+		if err := validator(value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateFlags validates each of the flags and flag groups, including the local flags of any
+// parent flagsets, returning a FlagValidationError if any of the flags fail validation.
+func validateFlags(metadata map[string]*flagMeta, groups []*flagGroup, get func(name string) interface{}, scopes ...*localScope) error {
+	failures := make(map[string]error)
+	addFailures(metadata, groups, get, false, failures)
+	for _, scope := range scopes {
+		addFailures(scope.metadata, scope.groups, scope.get, true, failures)
+	}
+	if len(failures) > 0 {
+		flagName := flagDisplayName(metadata, scopes)
+		displayNames := make(map[string]string, len(failures))
+		for name := range failures {
+			displayNames[name] = flagName(name)
+		}
+		return errors.FlagsetValidationFailed(failures, displayNames)
+	}
+	return nil
+}
+
+// flagDisplayName returns a function that formats a flag name as it is used on the command-line,
+// such as --output, using a single dash for any flag that has not been defined.
+func flagDisplayName(metadata map[string]*flagMeta, scopes []*localScope) func(name string) string {
+	return func(name string) string {
+		if meta, ok := metadata[name]; ok && meta.displayName != "" {
+			return meta.displayName
+		}
+		for _, scope := range scopes {
+			if meta, ok := scope.metadata[name]; ok && meta.displayName != "" {
+				return meta.displayName
+			}
+		}
+		return "-" + name
+	}
+}
+
+// addFailures adds a validation failure for each of the flags and flag groups that fail validation,
+// if localOnly is true then only the local flags are validated.
+func addFailures(metadata map[string]*flagMeta, groups []*flagGroup, get func(name string) interface{}, localOnly bool, failures map[string]error) {
+	for name, meta := range metadata {
+		if localOnly && !meta.local {
+			continue
+		}
+		if err := meta.validate(get(name)); err != nil {
+			failures[name] = err
+		}
+	}
+
+	isChanged := func(name string) bool {
+		meta, ok := metadata[name]
+		return ok && meta.isSet()
+	}
+	flagName := flagDisplayName(metadata, nil)
+	for _, group := range groups {
+		group.validate(isChanged, flagName, failures)
+	}
+}
@@ -0,0 +1,190 @@
+package flags
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/evilmonkeyinc/golang-cli/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Validators(t *testing.T) {
+
+	tests := []struct {
+		name      string
+		validator Validator
+		value     interface{}
+		expected  error
+	}{
+		{
+			name:      "min int",
+			validator: Min(1),
+			value:     int64(1),
+			expected:  nil,
+		},
+		{
+			name:      "min int too small",
+			validator: Min(1),
+			value:     int64(0),
+			expected:  fmt.Errorf("must be at least 1"),
+		},
+		{
+			name:      "min uint",
+			validator: Min(1),
+			value:     uint64(2),
+			expected:  nil,
+		},
+		{
+			name:      "min duration",
+			validator: Min(float64(time.Second)),
+			value:     time.Millisecond,
+			expected:  fmt.Errorf("must be at least 1e+09"),
+		},
+		{
+			name:      "min not a number",
+			validator: Min(1),
+			value:     "one",
+			expected:  fmt.Errorf("must be a number"),
+		},
+		{
+			name:      "max float",
+			validator: Max(1.5),
+			value:     float64(1.5),
+			expected:  nil,
+		},
+		{
+			name:      "max float too large",
+			validator: Max(1.5),
+			value:     float64(1.6),
+			expected:  fmt.Errorf("must be at most 1.5"),
+		},
+		{
+			name:      "max not a number",
+			validator: Max(1),
+			value:     true,
+			expected:  fmt.Errorf("must be a number"),
+		},
+		{
+			name:      "pattern",
+			validator: Pattern("^[a-z]+$"),
+			value:     "name",
+			expected:  nil,
+		},
+		{
+			name:      "pattern mismatch",
+			validator: Pattern("^[a-z]+$"),
+			value:     "Name",
+			expected:  fmt.Errorf("must match \"^[a-z]+$\""),
+		},
+		{
+			name:      "pattern not a string",
+			validator: Pattern("^[a-z]+$"),
+			value:     int64(1),
+			expected:  fmt.Errorf("must be a string"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual := test.validator(test.value)
+			if test.expected == nil {
+				assert.Nil(t, actual)
+			} else {
+				assert.EqualError(t, actual, test.expected.Error())
+			}
+		})
+	}
+
+	t.Run("pattern panics", func(t *testing.T) {
+		assert.Panics(t, func() {
+			Pattern("[")
+		})
+	})
+}
+
+func Test_flagMeta_validate(t *testing.T) {
+
+	failing := func(value interface{}) error {
+		return fmt.Errorf("invalid %v", value)
+	}
+
+	tests := []struct {
+		name     string
+		meta     *flagMeta
+		expected error
+	}{
+		{
+			name:     "not required",
+			meta:     &flagMeta{},
+			expected: nil,
+		},
+		{
+			name:     "required not changed",
+			meta:     &flagMeta{required: true},
+			expected: errRequired,
+		},
+		{
+			name:     "required changed",
+			meta:     &flagMeta{required: true, source: SourceCommandLine},
+			expected: nil,
+		},
+		{
+			name:     "validators not changed",
+			meta:     &flagMeta{validators: []Validator{failing}},
+			expected: nil,
+		},
+		{
+			name:     "validators changed",
+			meta:     &flagMeta{source: SourceCommandLine, validators: []Validator{failing}},
+			expected: fmt.Errorf("invalid value"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual := test.meta.validate("value")
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func Test_validateFlags(t *testing.T) {
+	get := func(name string) interface{} {
+		return int64(0)
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		actual := validateFlags(map[string]*flagMeta{
+			"count": {source: SourceCommandLine, validators: []Validator{Min(0)}},
+		}, nil, get)
+		assert.Nil(t, actual)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		actual := validateFlags(map[string]*flagMeta{
+			"count": {source: SourceCommandLine, validators: []Validator{Min(1)}},
+			"name":  {required: true},
+		}, nil, get)
+		assert.True(t, errors.IsFlagsetValidationFailed(actual))
+		assert.EqualError(t, actual, "flagset validation failed: -count must be at least 1, -name is required")
+	})
+
+	t.Run("local scopes", func(t *testing.T) {
+		scope := &localScope{
+			metadata: map[string]*flagMeta{
+				"id":      {required: true, local: true},
+				"name":    {required: true},
+				"json":    {source: SourceCommandLine, local: true},
+				"yaml":    {source: SourceCommandLine},
+				"verbose": {},
+			},
+			groups: []*flagGroup{newFlagGroup(atMostOneOf, []string{"json", "yaml"})},
+			get:    get,
+		}
+		actual := validateFlags(map[string]*flagMeta{
+			"count": {source: SourceCommandLine},
+		}, nil, get, scope)
+		assert.EqualError(t, actual, "flagset validation failed: -id is required, -yaml cannot be used with -json")
+	})
+}
@@ -0,0 +1,349 @@
+package flags
+
+import (
+	goerrors "errors"
+	"flag"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// errParse is returned by Set if a flag's value fails to parse, such as with an invalid integer.
+	errParse = goerrors.New("parse error")
+	// errRange is returned by Set if a flag's value is out of range.
+	errRange = goerrors.New("value out of range")
+)
+
+// numError converts a strconv error into a flag value error.
+func numError(err error) error {
+	if numErr, ok := err.(*strconv.NumError); ok {
+		if numErr.Err == strconv.ErrRange {
+			return errRange
+		}
+	}
+	return errParse
+}
+
+// The boolFlag interface describes a Value that does not require an argument,
+// such as a bool flag, and is compatible with the standard golang flag library.
+type boolFlag interface {
+	Value
+	IsBoolFlag() bool
+}
+
+// isBoolFlag returns true if the value does not require an argument.
+func isBoolFlag(value flag.Value) bool {
+	if boolValue, ok := value.(boolFlag); ok {
+		return boolValue.IsBoolFlag()
+	}
+	return false
+}
+
+type boolValue bool
+
+func newBoolValue(defaultValue bool) *boolValue {
+	value := boolValue(defaultValue)
+	return &value
+}
+
+func (value *boolValue) Set(s string) error {
+	parsed, err := strconv.ParseBool(s)
+	if err != nil {
+		return errParse
+	}
+	*value = boolValue(parsed)
+	return nil
+}
+
+func (value *boolValue) Get() interface{} { return bool(*value) }
+
+func (value *boolValue) String() string { return strconv.FormatBool(bool(*value)) }
+
+func (value *boolValue) IsBoolFlag() bool { return true }
+
+type countValue int64
+
+func newCountValue() *countValue {
+	value := countValue(0)
+	return &value
+}
+
+// Set increments the count each time the flag is used, and can be set directly using an integer,
+// which allows the count to be set using environment variables or configuration files.
+func (value *countValue) Set(s string) error {
+	switch s {
+	case "true":
+		*value++
+		return nil
+	case "false":
+		*value = 0
+		return nil
+	}
+	parsed, err := strconv.ParseInt(s, 0, 64)
+	if err != nil {
+		return numError(err)
+	}
+	*value = countValue(parsed)
+	return nil
+}
+
+func (value *countValue) Get() interface{} { return int64(*value) }
+
+func (value *countValue) String() string { return strconv.FormatInt(int64(*value), 10) }
+
+func (value *countValue) IsBoolFlag() bool { return true }
+
+type intValue int64
+
+func newIntValue(defaultValue int64) *intValue {
+	value := intValue(defaultValue)
+	return &value
+}
+
+func (value *intValue) Set(s string) error {
+	parsed, err := strconv.ParseInt(s, 0, 64)
+	if err != nil {
+		return numError(err)
+	}
+	*value = intValue(parsed)
+	return nil
+}
+
+func (value *intValue) Get() interface{} { return int64(*value) }
+
+func (value *intValue) String() string { return strconv.FormatInt(int64(*value), 10) }
+
+type uintValue uint64
+
+func newUintValue(defaultValue uint64) *uintValue {
+	value := uintValue(defaultValue)
+	return &value
+}
+
+func (value *uintValue) Set(s string) error {
+	parsed, err := strconv.ParseUint(s, 0, 64)
+	if err != nil {
+		return numError(err)
+	}
+	*value = uintValue(parsed)
+	return nil
+}
+
+func (value *uintValue) Get() interface{} { return uint64(*value) }
+
+func (value *uintValue) String() string { return strconv.FormatUint(uint64(*value), 10) }
+
+type stringValue string
+
+func newStringValue(defaultValue string) *stringValue {
+	value := stringValue(defaultValue)
+	return &value
+}
+
+func (value *stringValue) Set(s string) error {
+	*value = stringValue(s)
+	return nil
+}
+
+func (value *stringValue) Get() interface{} { return string(*value) }
+
+func (value *stringValue) String() string { return string(*value) }
+
+type floatValue float64
+
+func newFloatValue(defaultValue float64) *floatValue {
+	value := floatValue(defaultValue)
+	return &value
+}
+
+func (value *floatValue) Set(s string) error {
+	parsed, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return numError(err)
+	}
+	*value = floatValue(parsed)
+	return nil
+}
+
+func (value *floatValue) Get() interface{} { return float64(*value) }
+
+func (value *floatValue) String() string { return strconv.FormatFloat(float64(*value), 'g', -1, 64) }
+
+type durationValue time.Duration
+
+func newDurationValue(defaultValue time.Duration) *durationValue {
+	value := durationValue(defaultValue)
+	return &value
+}
+
+func (value *durationValue) Set(s string) error {
+	parsed, err := time.ParseDuration(s)
+	if err != nil {
+		return errParse
+	}
+	*value = durationValue(parsed)
+	return nil
+}
+
+func (value *durationValue) Get() interface{} { return time.Duration(*value) }
+
+func (value *durationValue) String() string { return time.Duration(*value).String() }
+
+// sliceValue contains the common behaviour of the slice values, where the first
+// call to Set replaces the default value and subsequent calls append to it.
+type sliceValue struct {
+	changed bool
+}
+
+// split splits the comma separated value and parses each item using the parse function.
+func (value *sliceValue) split(s string, parse func(item string) error) error {
+	for _, item := range strings.Split(s, ",") {
+		if err := parse(strings.TrimSpace(item)); err != nil {
+			return err
+		}
+	}
+	value.changed = true
+	return nil
+}
+
+// restore sets the default value using the set function, so that the next call to Set
+// will replace the default value rather than append to it.
+func (value *sliceValue) restore(defValue string, set func(string) error) error {
+	value.changed = false
+	if defValue == "" {
+		return nil
+	}
+	err := set(defValue)
+	value.changed = false
+	return err
+}
+
+type intSliceValue struct {
+	sliceValue
+	values []int64
+}
+
+func newIntSliceValue(defaultValue []int64) *intSliceValue {
+	return &intSliceValue{values: append([]int64{}, defaultValue...)}
+}
+
+func (value *intSliceValue) Set(s string) error {
+	parsed := []int64{}
+	if value.changed {
+		parsed = value.values
+	}
+	err := value.split(s, func(item string) error {
+		number, err := strconv.ParseInt(item, 0, 64)
+		if err != nil {
+			return numError(err)
+		}
+		parsed = append(parsed, number)
+		return nil
+	})
+	if err == nil {
+		value.values = parsed
+	}
+	return err
+}
+
+func (value *intSliceValue) reset(defValue string) error {
+	value.values = []int64{}
+	return value.restore(defValue, value.Set)
+}
+
+func (value *intSliceValue) Get() interface{} { return append([]int64{}, value.values...) }
+
+func (value *intSliceValue) String() string {
+	items := make([]string, len(value.values))
+	for i, number := range value.values {
+		items[i] = strconv.FormatInt(number, 10)
+	}
+	return strings.Join(items, ",")
+}
+
+type uintSliceValue struct {
+	sliceValue
+	values []uint64
+}
+
+func newUintSliceValue(defaultValue []uint64) *uintSliceValue {
+	return &uintSliceValue{values: append([]uint64{}, defaultValue...)}
+}
+
+func (value *uintSliceValue) Set(s string) error {
+	parsed := []uint64{}
+	if value.changed {
+		parsed = value.values
+	}
+	err := value.split(s, func(item string) error {
+		number, err := strconv.ParseUint(item, 0, 64)
+		if err != nil {
+			return numError(err)
+		}
+		parsed = append(parsed, number)
+		return nil
+	})
+	if err == nil {
+		value.values = parsed
+	}
+	return err
+}
+
+func (value *uintSliceValue) reset(defValue string) error {
+	value.values = []uint64{}
+	return value.restore(defValue, value.Set)
+}
+
+func (value *uintSliceValue) Get() interface{} { return append([]uint64{}, value.values...) }
+
+func (value *uintSliceValue) String() string {
+	items := make([]string, len(value.values))
+	for i, number := range value.values {
+		items[i] = strconv.FormatUint(number, 10)
+	}
+	return strings.Join(items, ",")
+}
+
+type floatSliceValue struct {
+	sliceValue
+	values []float64
+}
+
+func newFloatSliceValue(defaultValue []float64) *floatSliceValue {
+	return &floatSliceValue{values: append([]float64{}, defaultValue...)}
+}
+
+func (value *floatSliceValue) Set(s string) error {
+	parsed := []float64{}
+	if value.changed {
+		parsed = value.values
+	}
+	err := value.split(s, func(item string) error {
+		number, err := strconv.ParseFloat(item, 64)
+		if err != nil {
+			return numError(err)
+		}
+		parsed = append(parsed, number)
+		return nil
+	})
+	if err == nil {
+		value.values = parsed
+	}
+	return err
+}
+
+func (value *floatSliceValue) reset(defValue string) error {
+	value.values = []float64{}
+	return value.restore(defValue, value.Set)
+}
+
+func (value *floatSliceValue) Get() interface{} { return append([]float64{}, value.values...) }
+
+func (value *floatSliceValue) String() string {
+	items := make([]string, len(value.values))
+	for i, number := range value.values {
+		items[i] = strconv.FormatFloat(number, 'g', -1, 64)
+	}
+	return strings.Join(items, ",")
+}
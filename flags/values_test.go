@@ -0,0 +1,208 @@
+package flags
+
+import (
+	"flag"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Validate the value types match the standard golang flag library Getter interface
+var _ flag.Getter = newBoolValue(false)
+var _ flag.Getter = newIntValue(0)
+var _ flag.Getter = newUintValue(0)
+var _ flag.Getter = newStringValue("")
+var _ flag.Getter = newFloatValue(0)
+var _ flag.Getter = newDurationValue(0)
+var _ flag.Getter = newIntSliceValue(nil)
+var _ flag.Getter = newUintSliceValue(nil)
+var _ flag.Getter = newFloatSliceValue(nil)
+var _ flag.Getter = newCountValue()
+
+func Test_Values(t *testing.T) {
+
+	type expected struct {
+		err    error
+		value  interface{}
+		string string
+	}
+
+	tests := []struct {
+		name     string
+		value    Value
+		input    string
+		expected expected
+	}{
+		{
+			name:     "bool",
+			value:    newBoolValue(false),
+			input:    "true",
+			expected: expected{value: true, string: "true"},
+		},
+		{
+			name:     "bool invalid",
+			value:    newBoolValue(false),
+			input:    "yes please",
+			expected: expected{err: errParse, value: false, string: "false"},
+		},
+		{
+			name:     "int",
+			value:    newIntValue(0),
+			input:    "-12",
+			expected: expected{value: int64(-12), string: "-12"},
+		},
+		{
+			name:     "int hex",
+			value:    newIntValue(0),
+			input:    "0x10",
+			expected: expected{value: int64(16), string: "16"},
+		},
+		{
+			name:     "int invalid",
+			value:    newIntValue(1),
+			input:    "one",
+			expected: expected{err: errParse, value: int64(1), string: "1"},
+		},
+		{
+			name:     "int range",
+			value:    newIntValue(1),
+			input:    "99999999999999999999",
+			expected: expected{err: errRange, value: int64(1), string: "1"},
+		},
+		{
+			name:     "uint",
+			value:    newUintValue(0),
+			input:    "12",
+			expected: expected{value: uint64(12), string: "12"},
+		},
+		{
+			name:     "uint invalid",
+			value:    newUintValue(0),
+			input:    "-12",
+			expected: expected{err: errParse, value: uint64(0), string: "0"},
+		},
+		{
+			name:     "string",
+			value:    newStringValue(""),
+			input:    "value",
+			expected: expected{value: "value", string: "value"},
+		},
+		{
+			name:     "float",
+			value:    newFloatValue(0),
+			input:    "1.5",
+			expected: expected{value: 1.5, string: "1.5"},
+		},
+		{
+			name:     "float invalid",
+			value:    newFloatValue(0),
+			input:    "one",
+			expected: expected{err: errParse, value: float64(0), string: "0"},
+		},
+		{
+			name:     "duration",
+			value:    newDurationValue(0),
+			input:    "1m30s",
+			expected: expected{value: 90 * time.Second, string: "1m30s"},
+		},
+		{
+			name:     "duration invalid",
+			value:    newDurationValue(time.Second),
+			input:    "soon",
+			expected: expected{err: errParse, value: time.Second, string: "1s"},
+		},
+		{
+			name:     "count",
+			value:    newCountValue(),
+			input:    "true",
+			expected: expected{value: int64(1), string: "1"},
+		},
+		{
+			name:     "count integer",
+			value:    newCountValue(),
+			input:    "3",
+			expected: expected{value: int64(3), string: "3"},
+		},
+		{
+			name:     "count reset",
+			value:    newCountValue(),
+			input:    "false",
+			expected: expected{value: int64(0), string: "0"},
+		},
+		{
+			name:     "count invalid",
+			value:    newCountValue(),
+			input:    "many",
+			expected: expected{err: errParse, value: int64(0), string: "0"},
+		},
+		{
+			name:     "int slice",
+			value:    newIntSliceValue([]int64{1}),
+			input:    "2, 0x3",
+			expected: expected{value: []int64{2, 3}, string: "2,3"},
+		},
+		{
+			name:     "int slice invalid",
+			value:    newIntSliceValue([]int64{1}),
+			input:    "2,three",
+			expected: expected{err: errParse, value: []int64{1}, string: "1"},
+		},
+		{
+			name:     "uint slice",
+			value:    newUintSliceValue(nil),
+			input:    "1,2",
+			expected: expected{value: []uint64{1, 2}, string: "1,2"},
+		},
+		{
+			name:     "uint slice invalid",
+			value:    newUintSliceValue(nil),
+			input:    "-1",
+			expected: expected{err: errParse, value: []uint64{}, string: ""},
+		},
+		{
+			name:     "float slice",
+			value:    newFloatSliceValue([]float64{0.1}),
+			input:    "1.5,2",
+			expected: expected{value: []float64{1.5, 2}, string: "1.5,2"},
+		},
+		{
+			name:     "float slice invalid",
+			value:    newFloatSliceValue(nil),
+			input:    "1e999",
+			expected: expected{err: errRange, value: []float64{}, string: ""},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.value.Set(test.input)
+			assert.Equal(t, test.expected.err, err)
+			assert.Equal(t, test.expected.value, test.value.Get())
+			assert.Equal(t, test.expected.string, test.value.String())
+		})
+	}
+}
+
+func Test_sliceValue_Append(t *testing.T) {
+	value := newIntSliceValue([]int64{1})
+	assert.Nil(t, value.Set("2"))
+	assert.Nil(t, value.Set("3,4"))
+	assert.Equal(t, []int64{2, 3, 4}, value.Get())
+	assert.Equal(t, "2,3,4", value.String())
+}
+
+func Test_countValue_Increment(t *testing.T) {
+	value := newCountValue()
+	assert.Nil(t, value.Set("true"))
+	assert.Nil(t, value.Set("true"))
+	assert.Nil(t, value.Set("true"))
+	assert.Equal(t, int64(3), value.Get())
+}
+
+func Test_isBoolFlag(t *testing.T) {
+	assert.True(t, isBoolFlag(newBoolValue(false)))
+	assert.True(t, isBoolFlag(newCountValue()))
+	assert.False(t, isBoolFlag(newStringValue("")))
+	assert.False(t, isBoolFlag(&StringArrayFlag{}))
+}
@@ -2,10 +2,16 @@ module github.com/evilmonkeyinc/golang-cli
 
 go 1.17
 
-require github.com/stretchr/testify v1.8.2
+require (
+	github.com/BurntSushi/toml v1.3.2
+	github.com/stretchr/testify v1.8.2
+	golang.org/x/term v0.10.0
+	gopkg.in/ini.v1 v1.67.0
+	gopkg.in/yaml.v3 v3.0.1
+)
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
+	golang.org/x/sys v0.10.0 // indirect
 )
@@ -13,3 +13,20 @@ type Handler interface {
 	// Execute is used to execute the shell handler.
 	Execute(ResponseWriter, *Request) error
 }
+
+// The HiddenHandler interface describes a shell handler that can be hidden from
+// command listings while remaining routable.
+type HiddenHandler interface {
+	Handler
+	// IsHidden returns true if the handler should be excluded from command listings.
+	IsHidden() bool
+}
+
+// The DeprecatedHandler interface describes a shell handler that can be marked as deprecated.
+//
+// The router will output the deprecation message to the error writer whenever the handler is executed.
+type DeprecatedHandler interface {
+	Handler
+	// GetDeprecated returns the deprecation message, or an empty string if the handler is not deprecated.
+	GetDeprecated() string
+}
@@ -0,0 +1,71 @@
+package shell
+
+import (
+	"sync"
+
+	"github.com/evilmonkeyinc/golang-cli/flags"
+)
+
+// lazyRouter is a sub-router that will only execute its setup function
+// the first time the routing tree is evaluated.
+//
+// If the setup function panics, the panic is recovered and stored, and is
+// raised again every time the router is used, rather than leaving a router
+// that was only partially setup.
+type lazyRouter struct {
+	once     sync.Once
+	router   *StandardRouter
+	setupFn  func(r Router)
+	setupErr interface{}
+}
+
+func (lazy *lazyRouter) init() *StandardRouter {
+	lazy.once.Do(func() {
+		defer func() {
+			lazy.setupErr = recover()
+		}()
+		if lazy.setupFn != nil {
+			lazy.setupFn(lazy.router)
+		}
+	})
+	if lazy.setupErr != nil {
+		panic(lazy.setupErr)
+	}
+	return lazy.router
+}
+
+// Execute is used to execute the shell handler.
+func (lazy *lazyRouter) Execute(writer ResponseWriter, request *Request) error {
+	return lazy.init().Execute(writer, request)
+}
+
+// Define allows the function to define command-line flags.
+func (lazy *lazyRouter) Define(fd flags.FlagDefiner) {
+	lazy.init().Define(fd)
+}
+
+// Routes returns the linked shell handlers.
+func (lazy *lazyRouter) Routes() map[string]Handler {
+	return lazy.init().Routes()
+}
+
+// Groups returns the inline-routers added to the router stack.
+func (lazy *lazyRouter) Groups() []Router {
+	return lazy.init().Groups()
+}
+
+// Middlewares returns the list of middlewares in use by the router.
+func (lazy *lazyRouter) Middlewares() []Middleware {
+	return lazy.init().Middlewares()
+}
+
+// Match evaluates the routing tree for a handler that matches the supplied arguments
+// and returns the handler, wrapped in the appropriate middleware handler functions
+func (lazy *lazyRouter) Match(args []string) (Handler, bool) {
+	return lazy.init().Match(args)
+}
+
+// DiscoverPlugins returns the available plugin executables, keyed by command name.
+func (lazy *lazyRouter) DiscoverPlugins() map[string]string {
+	return lazy.init().DiscoverPlugins()
+}
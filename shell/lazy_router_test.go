@@ -0,0 +1,160 @@
+package shell
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/evilmonkeyinc/golang-cli/errors"
+	"github.com/evilmonkeyinc/golang-cli/flags"
+	"github.com/stretchr/testify/assert"
+)
+
+// Validate the lazyRouter struct matches the Routes interface
+var _ Routes = &lazyRouter{}
+
+// Validate the lazyRouter struct matches the GroupRoutes interface
+var _ GroupRoutes = &lazyRouter{}
+
+// Validate the lazyRouter struct matches the FlagHandler interface
+var _ flags.FlagHandler = &lazyRouter{}
+
+// Validate the lazyRouter struct matches the PluginRoutes interface
+var _ PluginRoutes = &lazyRouter{}
+
+func Test_Router_LazyRoute(t *testing.T) {
+
+	newLazyRouter := func(counter *int) *StandardRouter {
+		router := newRouter()
+		router.NotFound(HandlerFunction(func(ResponseWriter, *Request) error {
+			return fmt.Errorf("not found")
+		}))
+		router.LazyRoute("lazy", func(r Router) {
+			*counter++
+			r.Flags(flags.FlagHandlerFunction(func(fd flags.FlagDefiner) {
+				fd.Bool("lazy", false, "")
+			}))
+			r.HandleFunction("found", func(rw ResponseWriter, r *Request) error {
+				if lazy, _ := r.FlagValues().GetBool("lazy"); lazy {
+					return fmt.Errorf("found lazy")
+				}
+				return fmt.Errorf("found")
+			})
+		})
+		return router
+	}
+
+	t.Run("setup on execute", func(t *testing.T) {
+		counter := 0
+		router := newLazyRouter(&counter)
+		assert.Contains(t, router.handlers, "lazy")
+		assert.Equal(t, 0, counter)
+
+		request := NewRequest([]string{}, []string{"lazy", "-lazy", "found"}, flags.NewDefaultFlagSet(), router)
+		assert.EqualError(t, router.Execute(nil, request), "found lazy")
+		assert.Equal(t, 1, counter)
+
+		request = NewRequest([]string{}, []string{"lazy", "found"}, flags.NewDefaultFlagSet(), router)
+		assert.EqualError(t, router.Execute(nil, request), "found")
+		assert.Equal(t, 1, counter)
+
+		request = NewRequest([]string{}, []string{"lazy", "missing"}, flags.NewDefaultFlagSet(), router)
+		assert.EqualError(t, router.Execute(nil, request), "not found")
+		assert.Equal(t, 1, counter)
+	})
+
+	t.Run("no setup on sibling", func(t *testing.T) {
+		counter := 0
+		router := newLazyRouter(&counter)
+		router.HandleFunction("other", func(ResponseWriter, *Request) error {
+			return fmt.Errorf("other")
+		})
+
+		request := NewRequest([]string{}, []string{"other"}, flags.NewDefaultFlagSet(), router)
+		assert.EqualError(t, router.Execute(nil, request), "other")
+		assert.Equal(t, 0, counter)
+	})
+
+	t.Run("setup on walk", func(t *testing.T) {
+		counter := 0
+		router := newLazyRouter(&counter)
+
+		paths := []string{}
+		err := Walk(router, func(path []string, handler Handler, middlewares []Middleware) error {
+			paths = append(paths, fmt.Sprintf("%v", path))
+			return nil
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"[lazy]", "[lazy found]"}, paths)
+		assert.Equal(t, 1, counter)
+	})
+
+	t.Run("setup once concurrently", func(t *testing.T) {
+		counter := 0
+		router := newLazyRouter(&counter)
+		lazy := router.handlers["lazy"].(*lazyRouter)
+
+		wg := sync.WaitGroup{}
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, found := lazy.Match([]string{"found"})
+				assert.True(t, found)
+			}()
+		}
+		wg.Wait()
+		assert.Equal(t, 1, counter)
+	})
+
+	t.Run("setup panic", func(t *testing.T) {
+		counter := 0
+		router := newRouter()
+		router.LazyRoute("lazy", func(r Router) {
+			counter++
+			r.HandleFunction("found", func(ResponseWriter, *Request) error {
+				return nil
+			})
+			panic("setup failed")
+		})
+		lazy := router.handlers["lazy"].(*lazyRouter)
+
+		testPanic(t, func() {
+			lazy.Match([]string{"found"})
+		}, "setup failed")
+		testPanic(t, func() {
+			lazy.Match([]string{"found"})
+		}, "setup failed")
+		testPanic(t, func() {
+			request := NewRequest([]string{}, []string{"lazy", "found"}, flags.NewDefaultFlagSet(), router)
+			router.Execute(nil, request)
+		}, "setup failed")
+		assert.Equal(t, 1, counter)
+	})
+
+	t.Run("duplicate panic", func(t *testing.T) {
+		testPanic(t, func() {
+			router := newRouter()
+			router.LazyRoute("lazy", func(r Router) {})
+			router.LazyRoute("lazy", func(r Router) {})
+		}, errors.DuplicateCommand("lazy").Error())
+	})
+
+	t.Run("route setup", func(t *testing.T) {
+		counter := 0
+		router := newRouter()
+		router.Route("users", func(r Router) {
+			r.LazyRoute("admin", func(r Router) {
+				counter++
+				r.HandleFunction("add", func(ResponseWriter, *Request) error {
+					return fmt.Errorf("add")
+				})
+			})
+		})
+		assert.Equal(t, 0, counter)
+
+		request := NewRequest([]string{}, []string{"users", "admin", "add"}, flags.NewDefaultFlagSet(), router)
+		assert.EqualError(t, router.Execute(nil, request), "add")
+		assert.Equal(t, 1, counter)
+	})
+}
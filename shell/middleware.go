@@ -27,6 +27,20 @@ func (chain *chainHandler) Define(flagDefiner flags.FlagDefiner) {
 	}
 }
 
+func (chain *chainHandler) GetDeprecated() string {
+	if deprecatedHandler, ok := chain.handler.(DeprecatedHandler); ok {
+		return deprecatedHandler.GetDeprecated()
+	}
+	return ""
+}
+
+func (chain *chainHandler) IsHidden() bool {
+	if hiddenHandler, ok := chain.handler.(HiddenHandler); ok {
+		return hiddenHandler.IsHidden()
+	}
+	return false
+}
+
 func (chain *chainHandler) Execute(rw ResponseWriter, r *Request) error {
 	return chain.chain().Execute(rw, r)
 }
@@ -44,3 +58,16 @@ func (chain *chainHandler) chain() Handler {
 	}
 	return chainedHandler
 }
+
+// isRouter determines if the handler, once removed from any middleware chains, is a router.
+func isRouter(handler Handler) bool {
+	for {
+		chain, ok := handler.(*chainHandler)
+		if !ok {
+			break
+		}
+		handler = chain.handler
+	}
+	_, ok := handler.(Routes)
+	return ok
+}
@@ -131,6 +131,81 @@ func (option *flagSetOption) Apply(shell *Shell) error {
 	return nil
 }
 
+// OptionEnvPrefix shell option allows the user to set the prefix used to automatically
+// bind every flag to an environment variable, such as MYCLI_ binding the flag named
+// user-name to MYCLI_USER_NAME.
+func OptionEnvPrefix(prefix string) Option {
+	if prefix == "" {
+		panic(errors.OptionIsInvalid("EnvPrefix"))
+	}
+	return &envPrefixOption{
+		prefix: prefix,
+	}
+}
+
+type envPrefixOption struct {
+	prefix string
+}
+
+func (option *envPrefixOption) Apply(shell *Shell) error {
+	if shell.envPrefix != "" {
+		return errors.OptionIsSet("EnvPrefix")
+	}
+	shell.envPrefix = option.prefix
+	return nil
+}
+
+// OptionConfigFile shell option allows the user to set the path of the configuration file
+// used to set any flag that has not been set on the command-line or from environment variables.
+//
+// The file format is determined by the file extension, and supports JSON, YAML, TOML, and INI files.
+// The file is optional, so no configuration is loaded if the file does not exist.
+func OptionConfigFile(path string) Option {
+	if path == "" {
+		panic(errors.OptionIsInvalid("ConfigFile"))
+	}
+	return &configFileOption{
+		path: path,
+	}
+}
+
+type configFileOption struct {
+	path string
+}
+
+func (option *configFileOption) Apply(shell *Shell) error {
+	if shell.configFile != "" {
+		return errors.OptionIsSet("ConfigFile")
+	}
+	shell.configFile = option.path
+	return nil
+}
+
+// OptionConfigFlag shell option allows the user to define a root flag, such as config,
+// that can be used to set the path of the configuration file.
+//
+// The path set by OptionConfigFile will be used as the default value of the flag.
+func OptionConfigFlag(name string) Option {
+	if name == "" {
+		panic(errors.OptionIsInvalid("ConfigFlag"))
+	}
+	return &configFlagOption{
+		name: name,
+	}
+}
+
+type configFlagOption struct {
+	name string
+}
+
+func (option *configFlagOption) Apply(shell *Shell) error {
+	if shell.configFlag != "" {
+		return errors.OptionIsSet("ConfigFlag")
+	}
+	shell.configFlag = option.name
+	return nil
+}
+
 // OptionHelpHandler shell option allows the user to set the HelpHandler used by the shell.
 //
 // The HelpHandler will be executed whenever a handler returns the HelpRequested error.
@@ -155,6 +230,25 @@ func (option *helpHandlerOption) Apply(shell *Shell) error {
 	return nil
 }
 
+// OptionStickyFlags shell option allows the user to enable the setflag command in the interactive shell.
+//
+// When true, global flag values set using the setflag command, such as setflag toUpper true,
+// will be used by every command for the remainder of the session.
+func OptionStickyFlags(sticky bool) Option {
+	return &stickyFlagsOption{
+		sticky: sticky,
+	}
+}
+
+type stickyFlagsOption struct {
+	sticky bool
+}
+
+func (option *stickyFlagsOption) Apply(shell *Shell) error {
+	shell.sticky = option.sticky
+	return nil
+}
+
 // OptionExitOnError shell options allows the user to determine the shell behaviour.
 //
 // When true, the shell will exit when a handler returns an error.
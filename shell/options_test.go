@@ -221,6 +221,92 @@ func Test_OptionFlagSet(t *testing.T) {
 	})
 }
 
+func Test_OptionEnvPrefix(t *testing.T) {
+
+	t.Run("invalid", func(t *testing.T) {
+		expected := errors.OptionIsInvalid("EnvPrefix")
+		testPanic(t, func() {
+			OptionEnvPrefix("")
+		}, expected.Error())
+	})
+
+	t.Run("not set", func(t *testing.T) {
+		option := OptionEnvPrefix("MYCLI_")
+		shell := &Shell{}
+		err := option.Apply(shell)
+
+		assert.Equal(t, "MYCLI_", shell.envPrefix)
+		assert.Nil(t, err)
+	})
+
+	t.Run("already set", func(t *testing.T) {
+		option := OptionEnvPrefix("MYCLI_")
+		shell := &Shell{
+			envPrefix: "OTHER_",
+		}
+		err := option.Apply(shell)
+
+		assert.Equal(t, "OTHER_", shell.envPrefix)
+		assert.EqualValues(t, errors.OptionIsSet("EnvPrefix"), err)
+	})
+}
+
+func Test_OptionConfigFile(t *testing.T) {
+
+	t.Run("invalid", func(t *testing.T) {
+		expected := errors.OptionIsInvalid("ConfigFile")
+		testPanic(t, func() {
+			OptionConfigFile("")
+		}, expected.Error())
+	})
+
+	t.Run("not set", func(t *testing.T) {
+		shell := &Shell{}
+		err := OptionConfigFile("config.json").Apply(shell)
+
+		assert.Equal(t, "config.json", shell.configFile)
+		assert.Nil(t, err)
+	})
+
+	t.Run("already set", func(t *testing.T) {
+		shell := &Shell{
+			configFile: "other.json",
+		}
+		err := OptionConfigFile("config.json").Apply(shell)
+
+		assert.Equal(t, "other.json", shell.configFile)
+		assert.EqualValues(t, errors.OptionIsSet("ConfigFile"), err)
+	})
+}
+
+func Test_OptionConfigFlag(t *testing.T) {
+
+	t.Run("invalid", func(t *testing.T) {
+		expected := errors.OptionIsInvalid("ConfigFlag")
+		testPanic(t, func() {
+			OptionConfigFlag("")
+		}, expected.Error())
+	})
+
+	t.Run("not set", func(t *testing.T) {
+		shell := &Shell{}
+		err := OptionConfigFlag("config").Apply(shell)
+
+		assert.Equal(t, "config", shell.configFlag)
+		assert.Nil(t, err)
+	})
+
+	t.Run("already set", func(t *testing.T) {
+		shell := &Shell{
+			configFlag: "settings",
+		}
+		err := OptionConfigFlag("config").Apply(shell)
+
+		assert.Equal(t, "settings", shell.configFlag)
+		assert.EqualValues(t, errors.OptionIsSet("ConfigFlag"), err)
+	})
+}
+
 func Test_OptionHelpHandler(t *testing.T) {
 
 	t.Run("invalid", func(t *testing.T) {
@@ -295,3 +381,26 @@ func Test_OptionExitOnError(t *testing.T) {
 		assert.Nil(t, err)
 	})
 }
+
+func Test_OptionStickyFlags(t *testing.T) {
+
+	t.Run("not set", func(t *testing.T) {
+		option := OptionStickyFlags(true)
+		shell := &Shell{}
+		err := option.Apply(shell)
+
+		assert.Equal(t, true, shell.sticky)
+		assert.Nil(t, err)
+	})
+
+	t.Run("already set", func(t *testing.T) {
+		option := OptionStickyFlags(false)
+		shell := &Shell{
+			sticky: true,
+		}
+		err := option.Apply(shell)
+
+		assert.Equal(t, false, shell.sticky)
+		assert.Nil(t, err)
+	})
+}
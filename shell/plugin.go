@@ -0,0 +1,154 @@
+package shell
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/evilmonkeyinc/golang-cli/errors"
+	"github.com/evilmonkeyinc/golang-cli/flags"
+)
+
+// The PluginRoutes interface describes a router that supports external plugin executables.
+type PluginRoutes interface {
+	// DiscoverPlugins returns the available plugin executables, keyed by command name.
+	DiscoverPlugins() map[string]string
+}
+
+// NewPluginHandler returns a new PluginHandler for the specified prefix, which will search
+// the specified directories for plugin executables, or the PATH if no directories are specified.
+func NewPluginHandler(prefix string, dirs ...string) *PluginHandler {
+	return &PluginHandler{
+		Prefix: prefix,
+		Dirs:   dirs,
+	}
+}
+
+// PluginHandler is a shell handler that will execute external plugin executables,
+// named <prefix>-<command>, in the same way that `git foo` would execute `git-foo`.
+//
+// The plugin will be executed with the remaining request arguments, the request input,
+// and the response writer output and error writers. Flag values will be passed to the
+// plugin as environment variables in the format PREFIX_FLAGNAME, excluding secret flags
+// unless ExportSecrets is set.
+type PluginHandler struct {
+	// The prefix used to find plugin executables, typically the name of the binary.
+	Prefix string
+	// The directories that will be searched for plugin executables.
+	//
+	// If no directories are specified, the PATH environment variable will be used.
+	Dirs []string
+	// ExportSecrets will include the values of secret flags in the plugin environment variables.
+	//
+	// Secret flags are excluded by default, as the environment of a process can be read by other processes.
+	ExportSecrets bool
+}
+
+func (handler *PluginHandler) executableName(command string) string {
+	return fmt.Sprintf("%s-%s", handler.Prefix, command)
+}
+
+func (handler *PluginHandler) searchDirs() []string {
+	if len(handler.Dirs) > 0 {
+		return handler.Dirs
+	}
+	return filepath.SplitList(os.Getenv("PATH"))
+}
+
+func isExecutable(path string) bool {
+	info, err := os.Stat(path)
+	if err != nil || info.IsDir() {
+		return false
+	}
+	return info.Mode()&0111 != 0
+}
+
+// Lookup returns the path of the plugin executable for the specified command.
+func (handler *PluginHandler) Lookup(command string) (string, bool) {
+	if command == "" || strings.ContainsAny(command, `/\`) {
+		return "", false
+	}
+
+	name := handler.executableName(command)
+	if len(handler.Dirs) == 0 {
+		if path, err := exec.LookPath(name); err == nil {
+			return path, true
+		}
+		return "", false
+	}
+
+	for _, dir := range handler.Dirs {
+		path := filepath.Join(dir, name)
+		if isExecutable(path) {
+			return path, true
+		}
+	}
+	return "", false
+}
+
+// DiscoverPlugins returns the available plugin executables, keyed by command name.
+//
+// If the same plugin exists in multiple directories, the first directory takes precedence.
+func (handler *PluginHandler) DiscoverPlugins() map[string]string {
+	plugins := make(map[string]string)
+	prefix := handler.executableName("")
+	for _, dir := range handler.searchDirs() {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			name := entry.Name()
+			if !strings.HasPrefix(name, prefix) {
+				continue
+			}
+			command := strings.TrimPrefix(name, prefix)
+			if command == "" {
+				continue
+			}
+			if _, exists := plugins[command]; exists {
+				continue
+			}
+			path := filepath.Join(dir, name)
+			if isExecutable(path) {
+				plugins[command] = path
+			}
+		}
+	}
+	return plugins
+}
+
+// Execute is used to execute the plugin executable for the last element of the request path.
+func (handler *PluginHandler) Execute(writer ResponseWriter, request *Request) error {
+	command := ""
+	if len(request.Path) > 0 {
+		command = request.Path[len(request.Path)-1]
+	}
+
+	path, found := handler.Lookup(command)
+	if !found {
+		return errors.CommandNotFound(handler.executableName(command))
+	}
+
+	cmd := exec.CommandContext(request.Context(), path, request.Args...)
+	cmd.Stdin = request.Input
+	cmd.Stdout = writer
+	cmd.Stderr = writer.ErrorWriter()
+	cmd.Env = os.Environ()
+	if request.FlagSet != nil {
+		request.FlagSet.VisitAll(func(name string, value flags.Value) {
+			envValue := value.String()
+			if _, secret := value.(flags.SecretValue); secret {
+				if !handler.ExportSecrets {
+					return
+				}
+				// secret values are redacted when displayed, so the secret is passed to the plugin directly
+				envValue = fmt.Sprint(value.Get())
+			}
+			cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%s", flags.EnvName(handler.Prefix+"_", name), envValue))
+		})
+	}
+	return cmd.Run()
+}
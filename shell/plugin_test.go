@@ -0,0 +1,255 @@
+package shell
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/evilmonkeyinc/golang-cli/errors"
+	"github.com/evilmonkeyinc/golang-cli/flags"
+	"github.com/stretchr/testify/assert"
+)
+
+// Validate the PluginHandler struct matches the Handler interface
+var _ Handler = &PluginHandler{}
+
+// Validate the PluginHandler struct matches the PluginRoutes interface
+var _ PluginRoutes = &PluginHandler{}
+
+// Validate the StandardRouter struct matches the PluginRoutes interface
+var _ PluginRoutes = &StandardRouter{}
+
+// testPluginDir creates a temporary directory containing the specified plugin scripts
+func testPluginDir(t *testing.T, scripts map[string]string) string {
+	if runtime.GOOS == "windows" {
+		t.Skip("plugin scripts are not supported on windows")
+	}
+
+	dir := t.TempDir()
+	for name, script := range scripts {
+		err := os.WriteFile(filepath.Join(dir, name), []byte("#!/bin/sh\n"+script+"\n"), 0755)
+		assert.Nil(t, err)
+	}
+	err := os.WriteFile(filepath.Join(dir, "mycli-notexec"), []byte(""), 0644)
+	assert.Nil(t, err)
+	err = os.Mkdir(filepath.Join(dir, "mycli-dir"), 0755)
+	assert.Nil(t, err)
+	return dir
+}
+
+func Test_NewPluginHandler(t *testing.T) {
+	actual := NewPluginHandler("mycli", "one", "two")
+	assert.Equal(t, "mycli", actual.Prefix)
+	assert.Equal(t, []string{"one", "two"}, actual.Dirs)
+}
+
+func Test_PluginHandler_Lookup(t *testing.T) {
+	dir := testPluginDir(t, map[string]string{
+		"mycli-hello": "echo hello",
+	})
+
+	tests := []struct {
+		name     string
+		input    string
+		expected bool
+	}{
+		{
+			name:     "found",
+			input:    "hello",
+			expected: true,
+		},
+		{
+			name:     "missing",
+			input:    "missing",
+			expected: false,
+		},
+		{
+			name:     "not executable",
+			input:    "notexec",
+			expected: false,
+		},
+		{
+			name:     "directory",
+			input:    "dir",
+			expected: false,
+		},
+		{
+			name:     "empty",
+			input:    "",
+			expected: false,
+		},
+		{
+			name:     "path separator",
+			input:    "../mycli-hello",
+			expected: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			handler := NewPluginHandler("mycli", dir)
+			path, found := handler.Lookup(test.input)
+			assert.Equal(t, test.expected, found)
+			if test.expected {
+				assert.Equal(t, filepath.Join(dir, "mycli-"+test.input), path)
+			}
+		})
+	}
+
+	t.Run("PATH", func(t *testing.T) {
+		t.Setenv("PATH", dir)
+		handler := NewPluginHandler("mycli")
+		path, found := handler.Lookup("hello")
+		assert.True(t, found)
+		assert.Equal(t, filepath.Join(dir, "mycli-hello"), path)
+	})
+}
+
+func Test_PluginHandler_DiscoverPlugins(t *testing.T) {
+	first := testPluginDir(t, map[string]string{
+		"mycli-hello": "echo first",
+		"other-tool":  "echo other",
+	})
+	second := testPluginDir(t, map[string]string{
+		"mycli-hello": "echo second",
+		"mycli-world": "echo world",
+	})
+
+	expected := map[string]string{
+		"hello": filepath.Join(first, "mycli-hello"),
+		"world": filepath.Join(second, "mycli-world"),
+	}
+
+	t.Run("dirs", func(t *testing.T) {
+		handler := NewPluginHandler("mycli", first, second, filepath.Join(first, "missing"))
+		assert.Equal(t, expected, handler.DiscoverPlugins())
+	})
+
+	t.Run("PATH", func(t *testing.T) {
+		t.Setenv("PATH", strings.Join([]string{first, second}, string(os.PathListSeparator)))
+		handler := NewPluginHandler("mycli")
+		assert.Equal(t, expected, handler.DiscoverPlugins())
+	})
+}
+
+func Test_PluginHandler_Execute(t *testing.T) {
+	dir := testPluginDir(t, map[string]string{
+		"mycli-hello": `echo "args:$*"; echo "env:$MYCLI_TO_UPPER"; read line; echo "input:$line"; echo "error" >&2`,
+		"mycli-fail":  "exit 3",
+		"mycli-token": `echo "token:${MYCLI_TOKEN-unset}"`,
+	})
+
+	t.Run("execute", func(t *testing.T) {
+		flagSet := flags.NewDefaultFlagSet()
+		flagSet.Bool("to-upper", true, "")
+
+		outputWriter := &bytes.Buffer{}
+		errorWriter := &bytes.Buffer{}
+		writer := NewWrapperWriter(context.Background(), outputWriter, errorWriter)
+
+		request := NewRequest([]string{"hello"}, []string{"one", "two"}, flagSet, nil)
+		request.Input = strings.NewReader("from input\n")
+
+		handler := NewPluginHandler("mycli", dir)
+		err := handler.Execute(writer, request)
+		assert.Nil(t, err)
+		assert.Equal(t, "args:one two\nenv:true\ninput:from input\n", outputWriter.String())
+		assert.Equal(t, "error\n", errorWriter.String())
+	})
+
+	t.Run("secret", func(t *testing.T) {
+		flagSet := flags.NewDefaultFlagSet()
+		flagSet.Secret("token", "")
+		assert.Nil(t, flagSet.Set("token", "s3cret"))
+
+		outputWriter := &bytes.Buffer{}
+		writer := NewWrapperWriter(context.Background(), outputWriter, &bytes.Buffer{})
+		request := NewRequest([]string{"token"}, []string{}, flagSet, nil)
+
+		handler := NewPluginHandler("mycli", dir)
+		assert.Nil(t, handler.Execute(writer, request))
+		assert.Equal(t, "token:unset\n", outputWriter.String())
+
+		outputWriter.Reset()
+		handler.ExportSecrets = true
+		assert.Nil(t, handler.Execute(writer, request))
+		assert.Equal(t, "token:s3cret\n", outputWriter.String())
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		writer := NewWrapperWriter(context.Background(), &bytes.Buffer{}, &bytes.Buffer{})
+		request := NewRequest([]string{"fail"}, []string{}, nil, nil)
+
+		handler := NewPluginHandler("mycli", dir)
+		err := handler.Execute(writer, request)
+		assert.EqualError(t, err, "exit status 3")
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		writer := NewWrapperWriter(context.Background(), &bytes.Buffer{}, &bytes.Buffer{})
+		request := NewRequest([]string{"missing"}, []string{}, nil, nil)
+
+		handler := NewPluginHandler("mycli", dir)
+		err := handler.Execute(writer, request)
+		assert.Equal(t, errors.CommandNotFound("mycli-missing"), err)
+	})
+}
+
+func Test_Router_Plugins(t *testing.T) {
+	dir := testPluginDir(t, map[string]string{
+		"mycli-hello": `echo "hello $*"`,
+		"mycli-found": `echo "plugin"`,
+	})
+
+	router := newRouter()
+	router.HandleFunction("found", func(rw ResponseWriter, r *Request) error {
+		_, err := rw.Write([]byte("handler\n"))
+		return err
+	})
+	router.NotFound(HandlerFunction(func(rw ResponseWriter, r *Request) error {
+		return errors.CommandNotFound("test")
+	}))
+
+	assert.Equal(t, map[string]string{}, router.DiscoverPlugins())
+	router.Plugins(NewPluginHandler("mycli", dir))
+	assert.Len(t, router.DiscoverPlugins(), 2)
+
+	tests := []struct {
+		name     string
+		input    []string
+		expected string
+		err      error
+	}{
+		{
+			name:     "plugin",
+			input:    []string{"hello", "world"},
+			expected: "hello world\n",
+		},
+		{
+			name:     "handler takes precedence",
+			input:    []string{"found"},
+			expected: "handler\n",
+		},
+		{
+			name:  "not found",
+			input: []string{"missing"},
+			err:   errors.CommandNotFound("test"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			outputWriter := &bytes.Buffer{}
+			writer := NewWrapperWriter(context.Background(), outputWriter, &bytes.Buffer{})
+			request := NewRequest([]string{}, test.input, flags.NewDefaultFlagSet(), router)
+
+			err := router.Execute(writer, request)
+			assert.Equal(t, test.err, err)
+			assert.Equal(t, test.expected, outputWriter.String())
+		})
+	}
+}
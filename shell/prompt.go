@@ -0,0 +1,220 @@
+package shell
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/evilmonkeyinc/golang-cli/flags"
+)
+
+// isInteractive returns true if the input is a terminal, so the user can be prompted for input.
+var isInteractive = func(input io.Reader) bool {
+	_, ok := terminalFile(input)
+	return ok
+}
+
+// readPassword reads a single line from the input, without echoing it if the input is a terminal.
+var readPassword = func(input io.Reader) (string, error) {
+	if file, ok := terminalFile(input); ok {
+		return readSecret(file)
+	}
+	return readLine(input)
+}
+
+// NewPrompt returns a new Prompt that writes to the response writer and reads from the input,
+// which should be the request input so that the answers are read from the shell input.
+// If the input is nil, os.Stdin is used.
+func NewPrompt(writer ResponseWriter, input io.Reader) *Prompt {
+	if input == nil {
+		input = os.Stdin
+	}
+	return &Prompt{
+		writer: writer,
+		input:  input,
+	}
+}
+
+// A Prompt is used to ask the user for input, such as text, a confirmation,
+// a selection from a set of choices, or a password.
+type Prompt struct {
+	writer ResponseWriter
+	input  io.Reader
+}
+
+// Interactive returns true if the prompt input is a terminal.
+//
+// A prompt can be used with any input, but prompting for a missing value should
+// only be performed when the session is interactive.
+func (prompt *Prompt) Interactive() bool {
+	return isInteractive(prompt.input)
+}
+
+// Text prompts for a line of text, returning the default value if no text is entered.
+func (prompt *Prompt) Text(label, defaultValue string) (string, error) {
+	if defaultValue != "" {
+		fmt.Fprintf(prompt.writer, "%s [%s]: ", label, defaultValue)
+	} else {
+		fmt.Fprintf(prompt.writer, "%s: ", label)
+	}
+	value, err := readLine(prompt.input)
+	if err != nil {
+		return "", err
+	}
+	if value == "" {
+		return defaultValue, nil
+	}
+	return value, nil
+}
+
+// Confirm prompts for a yes or no answer, returning the default value if no answer is entered.
+// The prompt is repeated until a valid answer is entered.
+func (prompt *Prompt) Confirm(label string, defaultValue bool) (bool, error) {
+	options := "y/N"
+	if defaultValue {
+		options = "Y/n"
+	}
+	for {
+		fmt.Fprintf(prompt.writer, "%s [%s]: ", label, options)
+		value, err := readLine(prompt.input)
+		if err != nil {
+			return false, err
+		}
+		switch strings.ToLower(strings.TrimSpace(value)) {
+		case "":
+			return defaultValue, nil
+		case "y", "yes":
+			return true, nil
+		case "n", "no":
+			return false, nil
+		}
+		fmt.Fprintln(prompt.writer.ErrorWriter(), "Please answer yes or no")
+	}
+}
+
+// Select prompts for one of the choices, which can be selected using either its number
+// or its value, returning the default value if no choice is entered.
+// The prompt is repeated until a valid choice is entered.
+func (prompt *Prompt) Select(label string, choices []string, defaultValue string) (string, error) {
+	if len(choices) == 0 {
+		return "", fmt.Errorf("no choices to select from")
+	}
+
+	fmt.Fprintf(prompt.writer, "%s:\n", label)
+	for i, choice := range choices {
+		fmt.Fprintf(prompt.writer, "  %d) %s\n", i+1, choice)
+	}
+	for {
+		if defaultValue != "" {
+			fmt.Fprintf(prompt.writer, "Select [%s]: ", defaultValue)
+		} else {
+			fmt.Fprint(prompt.writer, "Select: ")
+		}
+		value, err := readLine(prompt.input)
+		if err != nil {
+			return "", err
+		}
+		value = strings.TrimSpace(value)
+		if value == "" && defaultValue != "" {
+			return defaultValue, nil
+		}
+		if index, err := strconv.Atoi(value); err == nil && index > 0 && index <= len(choices) {
+			return choices[index-1], nil
+		}
+		if contains(choices, value) {
+			return value, nil
+		}
+		fmt.Fprintf(prompt.writer.ErrorWriter(), "Please select a number between 1 and %d\n", len(choices))
+	}
+}
+
+// Password prompts for a secret, which is not echoed if the input is a terminal.
+func (prompt *Prompt) Password(label string) (string, error) {
+	fmt.Fprintf(prompt.writer, "%s: ", label)
+	value, err := readPassword(prompt.input)
+	if prompt.Interactive() {
+		// the newline is written as it is not echoed by the terminal
+		fmt.Fprintln(prompt.writer)
+	}
+	if err != nil {
+		return "", err
+	}
+	return value, nil
+}
+
+// flag prompts for the value of the flag until a valid value is entered. Secret flags are
+// prompted for as a password, flags with choices as a selection, and bool flags as a confirmation.
+//
+// If no value is entered the flag is left unset, so it will fail validation if it is required.
+func (prompt *Prompt) flag(flagSet flags.FlagSet, descriptor flags.FlagDescriptor) error {
+	for {
+		var value string
+		var err error
+		switch {
+		case descriptor.Secret:
+			value, err = prompt.Password(descriptor.Name)
+		case len(descriptor.Choices) > 0:
+			value, err = prompt.Select(descriptor.Name, descriptor.Choices, descriptor.Default)
+		case descriptor.Type == "bool":
+			var confirmed bool
+			confirmed, err = prompt.Confirm(descriptor.Name, descriptor.Default == "true")
+			value = strconv.FormatBool(confirmed)
+		default:
+			value, err = prompt.Text(descriptor.Name, descriptor.Default)
+		}
+		if err != nil {
+			return err
+		}
+		if value == "" {
+			return nil
+		}
+		if err := flagSet.Set(descriptor.Name, value); err != nil {
+			fmt.Fprintf(prompt.writer.ErrorWriter(), "Invalid value %q for %s\n", value, descriptor.Name)
+			continue
+		}
+		return nil
+	}
+}
+
+// isSecretFlag returns true if the flag with the specified name, or alias, is a secret flag.
+func isSecretFlag(flagSet flags.FlagSet, name string) bool {
+	for _, descriptor := range flagSet.Describe() {
+		if descriptor.Name == name || descriptor.Shorthand == name || contains(descriptor.Aliases, name) {
+			return descriptor.Secret
+		}
+	}
+	return false
+}
+
+func contains(values []string, value string) bool {
+	for _, existing := range values {
+		if existing == value {
+			return true
+		}
+	}
+	return false
+}
+
+// promptRequired prompts for the value of any required flag that has not been set, if the
+// input is a terminal, rather than failing validation. This also avoids required secrets
+// needing to be used on the command-line, where they would appear in the shell history.
+//
+// Optional flags are not prompted for, as flags defined by a router are inherited by
+// every command, so an optional secret would otherwise be prompted for by every command.
+func promptRequired(writer ResponseWriter, input io.Reader, flagSet flags.FlagSet) error {
+	prompt := NewPrompt(writer, input)
+	for _, descriptor := range flagSet.Describe() {
+		if !descriptor.Required || flagSet.Changed(descriptor.Name) {
+			continue
+		}
+		if !prompt.Interactive() {
+			return nil
+		}
+		if err := prompt.flag(flagSet, descriptor); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,289 @@
+package shell
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/evilmonkeyinc/golang-cli/flags"
+	"github.com/stretchr/testify/assert"
+)
+
+// withInteractive replaces the interactive check for the duration of the test.
+func withInteractive(t *testing.T, interactive bool) {
+	existing := isInteractive
+	t.Cleanup(func() {
+		isInteractive = existing
+	})
+	isInteractive = func(input io.Reader) bool {
+		return interactive
+	}
+}
+
+// newTestPrompt returns a prompt reading the input, and the buffers it writes to.
+func newTestPrompt(input string) (*Prompt, *bytes.Buffer, *bytes.Buffer) {
+	outputWriter := &bytes.Buffer{}
+	errorWriter := &bytes.Buffer{}
+	writer := NewWrapperWriter(context.Background(), outputWriter, errorWriter)
+	return NewPrompt(writer, strings.NewReader(input)), outputWriter, errorWriter
+}
+
+func Test_Prompt_Text(t *testing.T) {
+	tests := []struct {
+		input        string
+		defaultValue string
+		expected     string
+		expectedErr  string
+		output       string
+	}{
+		{input: "alice\n", expected: "alice", output: "Username: "},
+		{input: "alice\n", defaultValue: "bob", expected: "alice", output: "Username [bob]: "},
+		{input: "\n", defaultValue: "bob", expected: "bob", output: "Username [bob]: "},
+		{input: "\n", expected: "", output: "Username: "},
+		{input: "", expectedErr: "EOF", output: "Username: "},
+	}
+
+	for idx, test := range tests {
+		t.Run(fmt.Sprintf("%d", idx), func(t *testing.T) {
+			prompt, outputWriter, _ := newTestPrompt(test.input)
+			actual, err := prompt.Text("Username", test.defaultValue)
+			if test.expectedErr != "" {
+				assert.EqualError(t, err, test.expectedErr)
+			} else {
+				assert.Nil(t, err)
+			}
+			assert.Equal(t, test.expected, actual)
+			assert.Equal(t, test.output, outputWriter.String())
+		})
+	}
+}
+
+func Test_Prompt_Confirm(t *testing.T) {
+	tests := []struct {
+		input        string
+		defaultValue bool
+		expected     bool
+		expectedErr  string
+		output       string
+		errorOutput  string
+	}{
+		{input: "y\n", expected: true, output: "Continue [y/N]: "},
+		{input: "YES\n", expected: true, output: "Continue [y/N]: "},
+		{input: "n\n", defaultValue: true, expected: false, output: "Continue [Y/n]: "},
+		{input: "\n", defaultValue: true, expected: true, output: "Continue [Y/n]: "},
+		{input: "\n", expected: false, output: "Continue [y/N]: "},
+		{input: "maybe\nyes\n", expected: true, output: "Continue [y/N]: Continue [y/N]: ", errorOutput: "Please answer yes or no\n"},
+		{input: "maybe\n", expectedErr: "EOF", output: "Continue [y/N]: Continue [y/N]: ", errorOutput: "Please answer yes or no\n"},
+	}
+
+	for idx, test := range tests {
+		t.Run(fmt.Sprintf("%d", idx), func(t *testing.T) {
+			prompt, outputWriter, errorWriter := newTestPrompt(test.input)
+			actual, err := prompt.Confirm("Continue", test.defaultValue)
+			if test.expectedErr != "" {
+				assert.EqualError(t, err, test.expectedErr)
+			} else {
+				assert.Nil(t, err)
+			}
+			assert.Equal(t, test.expected, actual)
+			assert.Equal(t, test.output, outputWriter.String())
+			assert.Equal(t, test.errorOutput, errorWriter.String())
+		})
+	}
+}
+
+func Test_Prompt_Select(t *testing.T) {
+	choices := []string{"json", "yaml"}
+	menu := "Format:\n  1) json\n  2) yaml\n"
+
+	tests := []struct {
+		input        string
+		choices      []string
+		defaultValue string
+		expected     string
+		expectedErr  string
+		output       string
+		errorOutput  string
+	}{
+		{input: "2\n", choices: choices, expected: "yaml", output: menu + "Select: "},
+		{input: "json\n", choices: choices, expected: "json", output: menu + "Select: "},
+		{input: "\n", choices: choices, defaultValue: "yaml", expected: "yaml", output: menu + "Select [yaml]: "},
+		{input: "3\n1\n", choices: choices, expected: "json", output: menu + "Select: Select: ", errorOutput: "Please select a number between 1 and 2\n"},
+		{input: "\n", choices: choices, expectedErr: "EOF", output: menu + "Select: Select: ", errorOutput: "Please select a number between 1 and 2\n"},
+		{input: "1\n", expectedErr: "no choices to select from"},
+	}
+
+	for idx, test := range tests {
+		t.Run(fmt.Sprintf("%d", idx), func(t *testing.T) {
+			prompt, outputWriter, errorWriter := newTestPrompt(test.input)
+			actual, err := prompt.Select("Format", test.choices, test.defaultValue)
+			if test.expectedErr != "" {
+				assert.EqualError(t, err, test.expectedErr)
+			} else {
+				assert.Nil(t, err)
+			}
+			assert.Equal(t, test.expected, actual)
+			assert.Equal(t, test.output, outputWriter.String())
+			assert.Equal(t, test.errorOutput, errorWriter.String())
+		})
+	}
+}
+
+func Test_Prompt_Password(t *testing.T) {
+	t.Run("terminal", func(t *testing.T) {
+		withInteractive(t, true)
+		prompt, outputWriter, _ := newTestPrompt("s3cret\n")
+		actual, err := prompt.Password("Password")
+		assert.Nil(t, err)
+		assert.Equal(t, "s3cret", actual)
+		assert.Equal(t, "Password: \n", outputWriter.String())
+	})
+
+	t.Run("not a terminal", func(t *testing.T) {
+		withInteractive(t, false)
+		prompt, outputWriter, _ := newTestPrompt("s3cret\n")
+		actual, err := prompt.Password("Password")
+		assert.Nil(t, err)
+		assert.Equal(t, "s3cret", actual)
+		assert.Equal(t, "Password: ", outputWriter.String())
+	})
+}
+
+func Test_Prompt_Interactive(t *testing.T) {
+	prompt, _, _ := newTestPrompt("")
+	assert.False(t, prompt.Interactive())
+
+	withInteractive(t, true)
+	assert.True(t, prompt.Interactive())
+}
+
+func Test_isSecretFlag(t *testing.T) {
+	flagSet := flags.NewPosixFlagSet()
+	flagSet.Secret("password,p", "")
+	flagSet.Alias("password", "pass")
+	flagSet.String("name", "", "")
+
+	assert.True(t, isSecretFlag(flagSet, "password"))
+	assert.True(t, isSecretFlag(flagSet, "p"))
+	assert.True(t, isSecretFlag(flagSet, "pass"))
+	assert.False(t, isSecretFlag(flagSet, "name"))
+	assert.False(t, isSecretFlag(flagSet, "missing"))
+}
+
+func Test_promptRequired(t *testing.T) {
+	newFlagSet := func() flags.FlagSet {
+		flagSet := flags.NewDefaultFlagSet()
+		flagSet.Secret("password", "")
+		flagSet.Required("password")
+		flagSet.Secret("token", "")
+		flagSet.Enum("format", "", []string{"json", "yaml"}, "")
+		flagSet.Required("format")
+		flagSet.Int("port", 0, "")
+		flagSet.Required("port")
+		flagSet.Bool("force", false, "")
+		flagSet.Required("force")
+		flagSet.String("name", "", "")
+		return flagSet
+	}
+
+	t.Run("prompt", func(t *testing.T) {
+		withInteractive(t, true)
+		outputWriter := &bytes.Buffer{}
+		errorWriter := &bytes.Buffer{}
+		flagSet := newFlagSet()
+		writer := NewWrapperWriter(context.Background(), outputWriter, errorWriter)
+		input := strings.NewReader("y\nyaml\ns3cret\nabc\n8080\n")
+		assert.Nil(t, promptRequired(writer, input, flagSet))
+		assert.Equal(t, "force [y/N]: format:\n  1) json\n  2) yaml\nSelect: password: \nport [0]: port [0]: ", outputWriter.String())
+		assert.Equal(t, "Invalid value \"abc\" for port\n", errorWriter.String())
+
+		force, _ := flagSet.GetBool("force")
+		assert.True(t, force)
+		format, _ := flagSet.GetString("format")
+		assert.Equal(t, "yaml", format)
+		password, _ := flagSet.GetString("password")
+		assert.Equal(t, "s3cret", password)
+		port, _ := flagSet.GetInt("port")
+		assert.Equal(t, int64(8080), port)
+		assert.False(t, flagSet.Changed("token"))
+		assert.False(t, flagSet.Changed("name"))
+		assert.Nil(t, flagSet.Validate())
+	})
+
+	t.Run("already set", func(t *testing.T) {
+		withInteractive(t, true)
+		outputWriter := &bytes.Buffer{}
+		flagSet := newFlagSet()
+		_, err := flagSet.Parse([]string{"-password", "other", "-format", "json", "-port", "1", "-force"})
+		assert.Nil(t, err)
+		writer := NewWrapperWriter(context.Background(), outputWriter, &bytes.Buffer{})
+		assert.Nil(t, promptRequired(writer, strings.NewReader(""), flagSet))
+		assert.Empty(t, outputWriter.String())
+		password, _ := flagSet.GetString("password")
+		assert.Equal(t, "other", password)
+	})
+
+	t.Run("optional secret", func(t *testing.T) {
+		withInteractive(t, true)
+		outputWriter := &bytes.Buffer{}
+		flagSet := flags.NewDefaultFlagSet()
+		flagSet.Secret("token", "")
+		writer := NewWrapperWriter(context.Background(), outputWriter, &bytes.Buffer{})
+		assert.Nil(t, promptRequired(writer, strings.NewReader("t0ken\n"), flagSet))
+		assert.Empty(t, outputWriter.String())
+		assert.False(t, flagSet.Changed("token"))
+		assert.Nil(t, flagSet.Validate())
+	})
+
+	t.Run("no value entered", func(t *testing.T) {
+		withInteractive(t, true)
+		flagSet := flags.NewDefaultFlagSet()
+		flagSet.String("name", "", "")
+		flagSet.Required("name")
+		writer := NewWrapperWriter(context.Background(), &bytes.Buffer{}, &bytes.Buffer{})
+		assert.Nil(t, promptRequired(writer, strings.NewReader("\n"), flagSet))
+		assert.EqualError(t, flagSet.Validate(), "flagset validation failed: -name is required")
+	})
+
+	t.Run("not a terminal", func(t *testing.T) {
+		withInteractive(t, false)
+		outputWriter := &bytes.Buffer{}
+		flagSet := newFlagSet()
+		writer := NewWrapperWriter(context.Background(), outputWriter, &bytes.Buffer{})
+		assert.Nil(t, promptRequired(writer, strings.NewReader("s3cret\n"), flagSet))
+		assert.Empty(t, outputWriter.String())
+		assert.Error(t, flagSet.Validate())
+	})
+}
+
+func Test_Router_PromptRequired(t *testing.T) {
+	withInteractive(t, true)
+	router := newRouter()
+	// an optional secret defined by the router is inherited by every command, but is not prompted for
+	router.Flags(flags.FlagHandlerFunction(func(fd flags.FlagDefiner) {
+		fd.Secret("token", "")
+	}))
+	router.Handle("login", &testCommand{
+		define: func(fd flags.FlagDefiner) {
+			fd.String("username", "", "")
+			fd.Required("username")
+			fd.Secret("password", "")
+			fd.Required("password")
+		},
+		execute: func(rw ResponseWriter, r *Request) error {
+			username, _ := r.FlagValues().GetString("username")
+			password, _ := r.FlagValues().GetString("password")
+			return fmt.Errorf("%s:%s", username, password)
+		},
+	})
+
+	outputWriter := &bytes.Buffer{}
+	writer := NewWrapperWriter(context.Background(), outputWriter, &bytes.Buffer{})
+	request := NewRequest([]string{}, []string{"login", "-username", "alice"}, flags.NewDefaultFlagSet(), router)
+	request.Input = strings.NewReader("s3cret\nt0ken\n")
+	assert.EqualError(t, router.Execute(writer, request), "alice:s3cret")
+	assert.Equal(t, "password: \n", outputWriter.String())
+}
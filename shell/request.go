@@ -2,6 +2,7 @@ package shell
 
 import (
 	"context"
+	"io"
 	"strings"
 
 	"github.com/evilmonkeyinc/golang-cli/flags"
@@ -20,17 +21,22 @@ func NewRequestWithContext(ctx context.Context, path, args []string, flagSet fla
 		FlagSet: flagSet,
 		Path:    path,
 		Routes:  routes,
+		root:    routes,
 	}
 }
 
 // A Request represents the request sent by the shell and processed by the router and handlers.
 type Request struct {
-	ctx context.Context
+	ctx       context.Context
+	argValues map[string]interface{}
+	root      Routes
 
 	// Args contains the arguments passed as part of the request.
 	Args []string
 	// Flagset contains the flagset used to parse arguments.
 	FlagSet flags.FlagSet
+	// Input contains the reader used as the shell input.
+	Input io.Reader
 	// Path contains the request path.
 	Path []string
 	// Routes contains the router routes functions linked to the executed router.
@@ -42,6 +48,24 @@ func (request *Request) Context() context.Context {
 	return request.ctx
 }
 
+// Arg returns the value of the named positional argument, or nil if the argument was not declared or supplied.
+func (request *Request) Arg(name string) interface{} {
+	return request.argValues[name]
+}
+
+// Root returns the routes of the router the request was first sent to, which is the
+// root of the routing tree when the request was sent by the shell.
+func (request *Request) Root() Routes {
+	return request.root
+}
+
+// WithArgs returns a shallow copy of the request with the named positional argument values.
+func (request *Request) WithArgs(values map[string]interface{}) *Request {
+	newRequest := request.WithContext(request.ctx)
+	newRequest.argValues = values
+	return newRequest
+}
+
 // FlagValues returns the parsed flag values for the request flagset.
 func (request *Request) FlagValues() flags.FlagValues {
 	return request.FlagSet
@@ -56,11 +80,14 @@ func (request *Request) WithContext(ctx context.Context) *Request {
 	copy(path, request.Path)
 
 	return &Request{
-		ctx:     ctx,
-		Args:    args,
-		FlagSet: request.FlagSet,
-		Path:    path,
-		Routes:  request.Routes,
+		ctx:       ctx,
+		argValues: request.argValues,
+		Args:      args,
+		FlagSet:   request.FlagSet,
+		Input:     request.Input,
+		Path:      path,
+		Routes:    request.Routes,
+		root:      request.root,
 	}
 }
 
@@ -90,10 +117,13 @@ func (request *Request) UpdateRequest(selectedRoute string, args []string, flagS
 	}
 
 	return &Request{
-		ctx:     request.ctx,
-		Args:    args,
-		FlagSet: flagSet,
-		Path:    path,
-		Routes:  routes,
+		ctx:       request.ctx,
+		argValues: request.argValues,
+		Args:      args,
+		FlagSet:   flagSet,
+		Input:     request.Input,
+		Path:      path,
+		Routes:    routes,
+		root:      request.root,
 	}
 }
@@ -2,6 +2,7 @@ package shell
 
 import (
 	"context"
+	"strings"
 	"testing"
 
 	"github.com/evilmonkeyinc/golang-cli/flags"
@@ -59,6 +60,30 @@ func Test_Request_WithContext(t *testing.T) {
 	assert.Equal(t, actual.Context(), nextCtx)
 }
 
+func Test_Request_Arg(t *testing.T) {
+	actual := NewRequest([]string{}, []string{"bob"}, &flags.DefaultFlagSet{}, nil)
+	assert.Nil(t, actual.Arg("name"))
+
+	withArgs := actual.WithArgs(map[string]interface{}{"name": "bob"})
+	assert.Nil(t, actual.Arg("name"))
+	assert.Equal(t, "bob", withArgs.Arg("name"))
+	assert.Equal(t, "bob", withArgs.WithContext(context.Background()).Arg("name"))
+	assert.Equal(t, "bob", withArgs.UpdateRequest("", nil, nil, nil).Arg("name"))
+}
+
+func Test_Request_Root(t *testing.T) {
+	root := newRouter()
+	sub := newRouter()
+
+	actual := NewRequest([]string{}, []string{"users", "add"}, &flags.DefaultFlagSet{}, root)
+	assert.Equal(t, root, actual.Root())
+
+	updated := actual.UpdateRequest("users", nil, nil, sub)
+	assert.Equal(t, sub, updated.Routes)
+	assert.Equal(t, root, updated.Root())
+	assert.Equal(t, root, updated.WithContext(context.Background()).Root())
+}
+
 func Test_Request_FlagValues(t *testing.T) {
 
 	tests := []struct {
@@ -162,10 +187,14 @@ func Test_Request_UpdateRequest(t *testing.T) {
 			args := []string{"one", "two", "three", "four"}
 			path := []string{}
 
+			input := strings.NewReader("input")
+
 			original := NewRequestWithContext(ctx, path, args, &flags.DefaultFlagSet{}, nil)
+			original.Input = input
 			updated := original.UpdateRequest(test.input.selectedRoute, nil, nil, nil)
 
 			assert.Equal(t, ctx, updated.ctx)
+			assert.Equal(t, input, updated.Input)
 			assert.Equal(t, test.expected.args, updated.Args)
 			assert.Equal(t, test.expected.path, updated.Path)
 		})
@@ -33,6 +33,18 @@ type Router interface {
 	Mount(string, Router)
 	// Use appends one or more middleware onto the router stack.
 	Use(...Middleware)
+	// With adds a new inline-router, using the specified middleware, to the router stack.
+	With(...Middleware) Router
+	// LazyRoute adds a new sub-router to the router stack, along the specified command path,
+	// the setup function will not be executed until the sub-router is first evaluated.
+	LazyRoute(string, func(r Router))
+}
+
+// The PluginRouter interface describes a router that can execute external plugin executables.
+type PluginRouter interface {
+	// Plugins defines a plugin handler that will be used to execute external plugin
+	// executables if a command path cannot be evaluated.
+	Plugins(*PluginHandler)
 }
 
 // Routes interface describes functions for router traversal.
@@ -46,6 +58,13 @@ type Routes interface {
 	Match([]string) (Handler, bool)
 }
 
+// The GroupRoutes interface describes a router containing inline-routers, which are
+// evaluated at the same level as the containing router.
+type GroupRoutes interface {
+	// Groups returns the inline-routers added to the router stack.
+	Groups() []Router
+}
+
 // newRouter will return a new empty router
 func newRouter() *StandardRouter {
 	return &StandardRouter{
@@ -87,6 +106,7 @@ type StandardRouter struct {
 	middleware      []Middleware
 	notFoundHandler Handler
 	parent          Router
+	plugins         *PluginHandler
 }
 
 func (rtr *StandardRouter) setup() {
@@ -102,21 +122,48 @@ func (rtr *StandardRouter) Execute(writer ResponseWriter, request *Request) erro
 
 	if handler, found := rtr.Match(args); found {
 		currentRoute := args[0]
+		if deprecatedHandler, ok := handler.(DeprecatedHandler); ok {
+			if message := deprecatedHandler.GetDeprecated(); message != "" {
+				fmt.Fprintf(writer.ErrorWriter(), "Command %q is deprecated, %s\n", currentRoute, message)
+			}
+		}
 		flagSet = flagSet.SubFlagSet(currentRoute)
 		if flagHandler, ok := handler.(flags.FlagHandler); ok {
 			flagHandler.Define(flagSet)
 		}
 		var parseErr error = nil
-		if args, parseErr = flagSet.Parse(args[1:]); parseErr != nil {
+		if args, parseErr = parseFlags(flagSet, args[1:], isRouter(handler)); parseErr != nil {
 			if errors.IsHelpRequested(parseErr) {
 				return parseErr
 			}
 			fmt.Fprintln(writer.ErrorWriter(), parseErr.Error())
 		}
+		printFlagWarnings(writer, flagSet)
+		// flags can be set at any level so validation is only
+		// performed once the final command has been matched
+		if !isRouter(handler) {
+			if err := promptRequired(writer, request.Input, flagSet); err != nil {
+				return err
+			}
+			if err := flagSet.Validate(); err != nil {
+				return err
+			}
+		}
 		request = request.UpdateRequest(currentRoute, args, flagSet, rtr)
 		return handler.Execute(writer, request)
 	}
 
+	if rtr.plugins != nil && len(args) > 0 {
+		if _, found := rtr.plugins.Lookup(args[0]); found {
+			handler := &chainHandler{
+				handler:     rtr.plugins,
+				middlewares: rtr.middleware,
+			}
+			request = request.UpdateRequest(args[0], args, flagSet, rtr)
+			return handler.Execute(writer, request)
+		}
+	}
+
 	if rtr.notFoundHandler != nil {
 		handler := &chainHandler{
 			handler:     rtr.notFoundHandler,
@@ -129,6 +176,25 @@ func (rtr *StandardRouter) Execute(writer ResponseWriter, request *Request) erro
 	return nil
 }
 
+// parseFlags parses the flags for the current command.
+//
+// When routing, only the flags that precede the next command will be parsed, even if the
+// flagset allows interspersed flags, so that the remaining flags are left for the matched handler.
+func parseFlags(flagSet flags.FlagSet, args []string, routing bool) ([]string, error) {
+	if routing && flagSet.Interspersed() {
+		flagSet.SetInterspersed(false)
+		defer flagSet.SetInterspersed(true)
+	}
+	return flagSet.Parse(args)
+}
+
+// printFlagWarnings prints any warnings produced while parsing the flags, such as the use of a deprecated flag.
+func printFlagWarnings(writer ResponseWriter, flagSet flags.FlagSet) {
+	for _, warning := range flagSet.Warnings() {
+		fmt.Fprintln(writer.ErrorWriter(), warning)
+	}
+}
+
 // Define allows the function to define command-line flags.
 func (rtr *StandardRouter) Define(fd flags.FlagDefiner) {
 	if rtr.flags != nil {
@@ -141,6 +207,11 @@ func (rtr *StandardRouter) Routes() map[string]Handler {
 	return rtr.handlers
 }
 
+// Groups returns the inline-routers added to the router stack.
+func (rtr *StandardRouter) Groups() []Router {
+	return rtr.children
+}
+
 // Middlewares returns the list of middlewares in use by the router.
 func (rtr *StandardRouter) Middlewares() []Middleware {
 	return rtr.middleware
@@ -212,6 +283,20 @@ func (rtr *StandardRouter) NotFound(handler Handler) {
 	rtr.notFoundHandler = handler
 }
 
+// Plugins defines a plugin handler that will be used to execute external plugin
+// executables if a command path cannot be evaluated.
+func (rtr *StandardRouter) Plugins(handler *PluginHandler) {
+	rtr.plugins = handler
+}
+
+// DiscoverPlugins returns the available plugin executables, keyed by command name.
+func (rtr *StandardRouter) DiscoverPlugins() map[string]string {
+	if rtr.plugins == nil {
+		return map[string]string{}
+	}
+	return rtr.plugins.DiscoverPlugins()
+}
+
 // Route adds a new sub-router to the router stack, along the specified command path.
 func (rtr *StandardRouter) Route(command string, setup func(r Router)) Router {
 	rtr.setup()
@@ -225,6 +310,23 @@ func (rtr *StandardRouter) Route(command string, setup func(r Router)) Router {
 	return subRouter
 }
 
+// LazyRoute adds a new sub-router to the router stack, along the specified command path,
+// the setup function will not be executed until the sub-router is first evaluated.
+//
+// This can be used to reduce the start up time for large command trees or
+// for sub-routers that require expensive setup.
+func (rtr *StandardRouter) LazyRoute(command string, setup func(r Router)) {
+	rtr.setup()
+	if _, exists := rtr.Match([]string{command}); exists {
+		panic(errors.DuplicateCommand(command))
+	}
+
+	rtr.handlers[command] = &lazyRouter{
+		router:  subRouter(rtr),
+		setupFn: setup,
+	}
+}
+
 // Mount adds the specified router to the router stack, along the specified command path.
 //
 // A mounted router will not inherit helper functions, such as the not found handler,
@@ -243,3 +345,14 @@ func (rtr *StandardRouter) Mount(command string, router Router) {
 func (rtr *StandardRouter) Use(middleware ...Middleware) {
 	rtr.middleware = append(rtr.middleware, middleware...)
 }
+
+// With adds a new inline-router, using the specified middleware, to the router stack.
+//
+// This allows middleware to be applied to individual handlers without the need to
+// create a group, for example r.With(middleware).Handle("command", handler)
+func (rtr *StandardRouter) With(middleware ...Middleware) Router {
+	inlineRouter := childRouter(rtr)
+	inlineRouter.Use(middleware...)
+	rtr.children = append(rtr.children, inlineRouter)
+	return inlineRouter
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/evilmonkeyinc/golang-cli/errors"
@@ -14,6 +15,12 @@ import (
 // Validate the StandardRouter struct matches the Router interface
 var _ Router = &StandardRouter{}
 
+// Validate the StandardRouter struct matches the GroupRoutes interface
+var _ GroupRoutes = &StandardRouter{}
+
+// Validate the StandardRouter struct matches the PluginRouter interface
+var _ PluginRouter = &StandardRouter{}
+
 func Test_Router(t *testing.T) {
 
 	t.Run("newRouter", func(t *testing.T) {
@@ -74,6 +81,13 @@ func Test_Router(t *testing.T) {
 		assert.Equal(t, input.handlers, input.Routes())
 	})
 
+	t.Run("Groups", func(t *testing.T) {
+		input := newRouter()
+		input.children = append(input.children, newRouter())
+
+		assert.Equal(t, input.children, input.Groups())
+	})
+
 	t.Run("Middlewares", func(t *testing.T) {
 		input := newRouter()
 		input.middleware = append(input.middleware, MiddlewareFunction(func(next Handler) Handler { return next }))
@@ -288,6 +302,56 @@ func Test_Router_Group(t *testing.T) {
 	assert.Equal(t, direct, fmt.Errorf("found"))
 }
 
+func Test_Router_With(t *testing.T) {
+	router := &StandardRouter{}
+	router.HandleFunction("other", func(ResponseWriter, *Request) error {
+		return fmt.Errorf("other")
+	})
+	actual := router.With(MiddlewareFunction(func(next Handler) Handler {
+		return HandlerFunction(func(rw ResponseWriter, r *Request) error {
+			err := next.Execute(rw, r)
+			return fmt.Errorf("%w with middleware", err)
+		})
+	}))
+	actual.HandleFunction("found", func(ResponseWriter, *Request) error {
+		return fmt.Errorf("found")
+	})
+
+	assert.Contains(t, router.children, actual)
+	assert.Len(t, actual.Middlewares(), 1)
+	assert.Len(t, router.Middlewares(), 0)
+
+	request := NewRequest([]string{}, []string{"found"}, &flags.DefaultFlagSet{}, nil)
+	assert.EqualError(t, router.Execute(nil, request), "found with middleware")
+
+	request = NewRequest([]string{}, []string{"other"}, &flags.DefaultFlagSet{}, nil)
+	assert.EqualError(t, router.Execute(nil, request), "other")
+
+	t.Run("duplicate panic", func(t *testing.T) {
+		testPanic(t, func() {
+			router.HandleFunction("found", func(ResponseWriter, *Request) error {
+				return nil
+			})
+		}, errors.DuplicateCommand("found").Error())
+	})
+
+	t.Run("route setup", func(t *testing.T) {
+		router := &StandardRouter{}
+		router.Route("users", func(r Router) {
+			r.With(MiddlewareFunction(func(next Handler) Handler {
+				return HandlerFunction(func(rw ResponseWriter, r *Request) error {
+					return fmt.Errorf("%w with middleware", next.Execute(rw, r))
+				})
+			})).HandleFunction("delete", func(ResponseWriter, *Request) error {
+				return fmt.Errorf("delete")
+			})
+		})
+
+		request := NewRequest([]string{}, []string{"users", "delete"}, &flags.DefaultFlagSet{}, nil)
+		assert.EqualError(t, router.Execute(nil, request), "delete with middleware")
+	})
+}
+
 func Test_Router_Route(t *testing.T) {
 	t.Run("set route", func(t *testing.T) {
 		router := &StandardRouter{}
@@ -667,3 +731,295 @@ func Test_Router_Flags(t *testing.T) {
 		})
 	}
 }
+
+type testDeprecatedHandler struct {
+	deprecated string
+}
+
+func (handler *testDeprecatedHandler) GetDeprecated() string {
+	return handler.deprecated
+}
+
+func (handler *testDeprecatedHandler) Execute(ResponseWriter, *Request) error {
+	return fmt.Errorf("executed")
+}
+
+func Test_Router_Deprecated(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		input    []string
+		expected string
+	}{
+		{
+			name:     "deprecated",
+			input:    []string{"old"},
+			expected: "Command \"old\" is deprecated, use new instead\n",
+		},
+		{
+			name:     "deprecated in group",
+			input:    []string{"grouped"},
+			expected: "Command \"grouped\" is deprecated, use new instead\n",
+		},
+		{
+			name:     "not deprecated",
+			input:    []string{"new"},
+			expected: "",
+		},
+	}
+
+	router := &StandardRouter{}
+	router.Handle("old", &testDeprecatedHandler{deprecated: "use new instead"})
+	router.Handle("new", &testDeprecatedHandler{})
+	router.Group(func(r Router) {
+		r.Handle("grouped", &testDeprecatedHandler{deprecated: "use new instead"})
+	})
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			errWriter := &bytes.Buffer{}
+			writer := NewWrapperWriter(context.Background(), &bytes.Buffer{}, errWriter)
+			request := NewRequest([]string{}, test.input, flags.NewDefaultFlagSet(), router)
+
+			actual := router.Execute(writer, request)
+			assert.EqualError(t, actual, "executed")
+			assert.Equal(t, test.expected, errWriter.String())
+		})
+	}
+}
+
+func Test_Router_Interspersed(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		input    []string
+		expected string
+	}{
+		{
+			name:     "flags before args",
+			input:    []string{"users", "add", "-admin", "bob"},
+			expected: "[bob] admin=true role= verbose=false",
+		},
+		{
+			name:     "flags after args",
+			input:    []string{"users", "add", "bob", "-admin"},
+			expected: "[bob] admin=true role= verbose=false",
+		},
+		{
+			name:     "parent flags after args",
+			input:    []string{"users", "add", "bob", "-role", "owner", "-verbose"},
+			expected: "[bob] admin=false role=owner verbose=true",
+		},
+		{
+			name:     "parent flags in route",
+			input:    []string{"-verbose", "users", "-role", "owner", "add", "bob"},
+			expected: "[bob] admin=false role=owner verbose=true",
+		},
+		{
+			name:     "terminator",
+			input:    []string{"users", "add", "bob", "--", "-admin"},
+			expected: "[bob -admin] admin=false role= verbose=false",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			router := newRouter()
+			router.Flags(flags.FlagHandlerFunction(func(fd flags.FlagDefiner) {
+				fd.Bool("verbose", false, "")
+			}))
+			router.Route("users", func(r Router) {
+				r.Flags(flags.FlagHandlerFunction(func(fd flags.FlagDefiner) {
+					fd.String("role", "", "")
+				}))
+				r.Handle("add", &testCommand{
+					define: func(fd flags.FlagDefiner) {
+						fd.Bool("admin", false, "")
+					},
+					execute: func(rw ResponseWriter, r *Request) error {
+						admin, _ := r.FlagValues().GetBool("admin")
+						role, _ := r.FlagValues().GetString("role")
+						verbose, _ := r.FlagValues().GetBool("verbose")
+						return fmt.Errorf("%v admin=%v role=%s verbose=%v", r.Args, admin, role, verbose)
+					},
+				})
+			})
+
+			flagSet := flags.NewDefaultFlagSet()
+			flagSet.SetInterspersed(true)
+
+			errWriter := &bytes.Buffer{}
+			writer := NewWrapperWriter(context.Background(), &bytes.Buffer{}, errWriter)
+
+			rootFlagSet := flagSet.SubFlagSet("")
+			router.Define(rootFlagSet)
+			args, err := parseFlags(rootFlagSet, test.input, true)
+			assert.Nil(t, err)
+
+			request := NewRequest([]string{}, args, rootFlagSet, router)
+			actual := router.Execute(writer, request)
+			assert.EqualError(t, actual, test.expected)
+			assert.Equal(t, "", errWriter.String())
+			assert.True(t, rootFlagSet.Interspersed())
+		})
+	}
+}
+
+func Test_Router_Validate(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		input    []string
+		expected string
+	}{
+		{
+			name:     "valid",
+			input:    []string{"-token", "abc", "users", "add", "-age", "21"},
+			expected: "token=abc age=21",
+		},
+		{
+			name:     "global flag after route",
+			input:    []string{"users", "-token", "abc", "add"},
+			expected: "token=abc age=18",
+		},
+		{
+			name:     "invalid",
+			input:    []string{"users", "add", "-age", "12"},
+			expected: "flagset validation failed: -age must be at least 18, -token is required",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			router := newRouter()
+			router.Flags(flags.FlagHandlerFunction(func(fd flags.FlagDefiner) {
+				fd.String("token", "", "")
+				fd.Required("token")
+			}))
+			router.Route("users", func(r Router) {
+				r.Handle("add", &testCommand{
+					define: func(fd flags.FlagDefiner) {
+						fd.Int("age", 18, "")
+						fd.Validators("age", flags.Min(18))
+					},
+					execute: func(rw ResponseWriter, r *Request) error {
+						token, _ := r.FlagValues().GetString("token")
+						age, _ := r.FlagValues().GetInt("age")
+						return fmt.Errorf("token=%s age=%d", token, age)
+					},
+				})
+			})
+
+			rootFlagSet := flags.NewDefaultFlagSet().SubFlagSet("")
+			router.Define(rootFlagSet)
+			args, err := parseFlags(rootFlagSet, test.input, true)
+			assert.Nil(t, err)
+
+			writer := NewWrapperWriter(context.Background(), &bytes.Buffer{}, &bytes.Buffer{})
+			request := NewRequest([]string{}, args, rootFlagSet, router)
+			actual := router.Execute(writer, request)
+			assert.EqualError(t, actual, test.expected)
+		})
+	}
+}
+
+func Test_Router_LocalFlags(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		input    []string
+		expected string
+	}{
+		{
+			name:     "valid",
+			input:    []string{"-verbose", "users", "-org", "acme", "add", "-name", "bob"},
+			expected: "verbose=true org=<nil> name=bob",
+		},
+		{
+			name:     "local flag after route",
+			input:    []string{"users", "add", "-org", "acme"},
+			expected: "flagset parse failed flag provided but not defined: -org",
+		},
+		{
+			name:     "local flag required",
+			input:    []string{"users", "add"},
+			expected: "flagset validation failed: -org is required",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			router := newRouter()
+			router.Flags(flags.FlagHandlerFunction(func(fd flags.FlagDefiner) {
+				fd.Bool("verbose", false, "")
+			}))
+			router.Route("users", func(r Router) {
+				r.Flags(flags.FlagHandlerFunction(func(fd flags.FlagDefiner) {
+					fd.String("org", "", "")
+					fd.Local("org")
+					fd.Required("org")
+				}))
+				r.Handle("add", &testCommand{
+					define: func(fd flags.FlagDefiner) {
+						fd.String("name", "", "")
+					},
+					execute: func(rw ResponseWriter, r *Request) error {
+						verbose, _ := r.FlagValues().GetBool("verbose")
+						name, _ := r.FlagValues().GetString("name")
+						return fmt.Errorf("verbose=%v org=%v name=%s", verbose, r.FlagValues().Get("org"), name)
+					},
+				})
+			})
+
+			rootFlagSet := flags.NewDefaultFlagSet().SubFlagSet("")
+			router.Define(rootFlagSet)
+			args, err := parseFlags(rootFlagSet, test.input, true)
+			assert.Nil(t, err)
+
+			errorWriter := &bytes.Buffer{}
+			writer := NewWrapperWriter(context.Background(), &bytes.Buffer{}, errorWriter)
+			request := NewRequest([]string{}, args, rootFlagSet, router)
+			actual := router.Execute(writer, request)
+			if errorWriter.Len() > 0 {
+				actual = fmt.Errorf("%s", strings.TrimSpace(errorWriter.String()))
+			}
+			assert.EqualError(t, actual, test.expected)
+		})
+	}
+}
+
+func Test_Router_DeprecatedFlagAlias(t *testing.T) {
+	router := newRouter()
+	router.Handle("add", &testCommand{
+		define: func(fd flags.FlagDefiner) {
+			fd.String("name", "", "")
+			fd.DeprecatedAlias("name", "username", "use -name instead")
+		},
+		execute: func(rw ResponseWriter, r *Request) error {
+			name, _ := r.FlagValues().GetString("name")
+			fmt.Fprint(rw, name)
+			return nil
+		},
+	})
+
+	outputWriter := &bytes.Buffer{}
+	errorWriter := &bytes.Buffer{}
+	writer := NewWrapperWriter(context.Background(), outputWriter, errorWriter)
+	request := NewRequest([]string{}, []string{"add", "-username", "bob"}, flags.NewDefaultFlagSet(), router)
+	assert.Nil(t, router.Execute(writer, request))
+	assert.Equal(t, "bob", outputWriter.String())
+	assert.Equal(t, "Flag \"-username\" is deprecated, use -name instead\n", errorWriter.String())
+}
+
+type testCommand struct {
+	define  func(flags.FlagDefiner)
+	execute HandlerFunction
+}
+
+func (command *testCommand) Define(fd flags.FlagDefiner) {
+	command.define(fd)
+}
+
+func (command *testCommand) Execute(rw ResponseWriter, r *Request) error {
+	return command.execute(rw, r)
+}
@@ -6,14 +6,17 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"sort"
 	"strings"
 
+	"github.com/evilmonkeyinc/golang-cli/config"
 	"github.com/evilmonkeyinc/golang-cli/errors"
 	"github.com/evilmonkeyinc/golang-cli/flags"
 )
 
 const (
 	defaultShellPrompt string = "shell>"
+	setFlagCommand     string = "setflag"
 )
 
 // Shell exposes the command-line or interactive shell functionality.
@@ -22,6 +25,9 @@ const (
 // or to be run as an interactive shell using the Start function
 type Shell struct {
 	closed       chan struct{}
+	configFile   string
+	configFlag   string
+	envPrefix    string
 	errorWriter  io.Writer
 	flagSet      flags.FlagSet
 	helpHandler  Handler
@@ -30,6 +36,9 @@ type Shell struct {
 	router       Router
 	shellPrompt  string
 	exitOnError  bool
+	sticky       bool
+	stickyFlags  map[string]string
+	secretFlags  map[string]bool
 }
 
 func (shell *Shell) setup() {
@@ -42,6 +51,9 @@ func (shell *Shell) setup() {
 	if shell.flagSet == nil {
 		shell.flagSet = flags.NewDefaultFlagSet()
 	}
+	if shell.envPrefix != "" {
+		shell.flagSet.SetEnvPrefix(shell.envPrefix)
+	}
 	if shell.outputWriter == nil {
 		shell.outputWriter = os.Stdout
 	}
@@ -54,6 +66,12 @@ func (shell *Shell) setup() {
 	if shell.shellPrompt == "" {
 		shell.shellPrompt = defaultShellPrompt
 	}
+	if shell.stickyFlags == nil {
+		shell.stickyFlags = make(map[string]string)
+	}
+	if shell.secretFlags == nil {
+		shell.secretFlags = make(map[string]bool)
+	}
 }
 
 func (shell *Shell) execute(ctx context.Context, args []string) error {
@@ -61,29 +79,146 @@ func (shell *Shell) execute(ctx context.Context, args []string) error {
 
 	flagSet := shell.flagSet
 	if flagHandler, ok := shell.router.(flags.FlagHandler); ok {
-		flagSet = flagSet.SubFlagSet("")
-		flagHandler.Define(flagSet)
+		flagSet = shell.defineFlags(flagHandler)
+		if err := shell.applyStickyFlags(flagSet); err != nil {
+			return err
+		}
 		var parseErr error = nil
-		if args, parseErr = flagSet.Parse(args); parseErr != nil {
+		if args, parseErr = parseFlags(flagSet, args, true); parseErr != nil {
 			if errors.IsHelpRequested(parseErr) && shell.helpHandler != nil {
 				request := NewRequestWithContext(ctx, []string{}, args, flagSet, shell.router)
+				request.Input = shell.reader
 				return shell.helpHandler.Execute(writer, request)
 			}
 			fmt.Fprintln(writer.ErrorWriter(), parseErr.Error())
 		}
+		printFlagWarnings(writer, flagSet)
+		if err := shell.loadConfig(flagSet); err != nil {
+			return err
+		}
 	}
 
 	request := NewRequestWithContext(ctx, []string{}, args, flagSet, shell.router)
+	request.Input = shell.reader
 	if err := shell.router.Execute(writer, request); err != nil {
 		if errors.IsHelpRequested(err) && shell.helpHandler != nil {
 			return shell.helpHandler.Execute(writer, request)
 		}
+		if (errors.IsFlagsetValidationFailed(err) || errors.IsArgsValidationFailed(err)) && shell.helpHandler != nil {
+			// display the usage for the command before returning the validation failure
+			if helpErr := shell.helpHandler.Execute(writer, request); helpErr != nil {
+				return helpErr
+			}
+		}
 		return err
 	}
 
 	return nil
 }
 
+// defineFlags returns a new flagset containing the global flags.
+func (shell *Shell) defineFlags(flagHandler flags.FlagHandler) flags.FlagSet {
+	flagSet := shell.flagSet.SubFlagSet("")
+	// flag values read from the input using @- share the shell input, which is
+	// limited to a single line in an interactive shell as it also contains the commands
+	if buffered, ok := shell.reader.(*bufferedInput); ok {
+		flagSet.SetInput(&lineInput{input: buffered})
+	} else {
+		flagSet.SetInput(shell.reader)
+	}
+	if shell.configFlag != "" {
+		flagSet.String(shell.configFlag, shell.configFile, "the configuration file `path`")
+	}
+	flagHandler.Define(flagSet)
+	return flagSet
+}
+
+// applyStickyFlags sets the flag values that have been set using the setflag command.
+func (shell *Shell) applyStickyFlags(flagSet flags.FlagSet) error {
+	names := make([]string, 0, len(shell.stickyFlags))
+	for name := range shell.stickyFlags {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if err := flagSet.Set(name, shell.stickyFlags[name]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setFlag executes the setflag command, which sets a global flag value that will be used by
+// every command for the remainder of the interactive session.
+//
+// The command will list the flag values when used without arguments,
+// and will remove the flag value when used with only the flag name.
+func (shell *Shell) setFlag(args []string) error {
+	switch len(args) {
+	case 0:
+		names := make([]string, 0, len(shell.stickyFlags))
+		for name := range shell.stickyFlags {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			value := shell.stickyFlags[name]
+			if shell.secretFlags[name] {
+				value = flags.RedactedValue
+			}
+			fmt.Fprintf(shell.outputWriter, "%s=%s\n", name, value)
+		}
+		return nil
+	case 1:
+		delete(shell.stickyFlags, args[0])
+		delete(shell.secretFlags, args[0])
+		return nil
+	case 2:
+		// the flag value is set on the global flags to ensure it is valid
+		// before the flags are reset to their initial state
+		defer shell.flagSet.Reset()
+		flagSet := shell.flagSet
+		if flagHandler, ok := shell.router.(flags.FlagHandler); ok {
+			flagSet = shell.defineFlags(flagHandler)
+		}
+		if err := flagSet.Set(args[0], args[1]); err != nil {
+			return err
+		}
+		shell.stickyFlags[args[0]] = args[1]
+		shell.secretFlags[args[0]] = isSecretFlag(flagSet, args[0])
+		return nil
+	}
+	return errors.FlagsetSetFailed(fmt.Sprintf("usage: %s [name [value]]", setFlagCommand))
+}
+
+// loadConfig loads the configuration file, if one has been set, and adds it to the flagset.
+//
+// The default configuration file is ignored if it does not exist, while a path
+// set using the configuration flag is required to exist.
+func (shell *Shell) loadConfig(flagSet flags.FlagSet) error {
+	path := shell.configFile
+	explicit := false
+	if shell.configFlag != "" {
+		path, _ = flagSet.GetString(shell.configFlag)
+		explicit = flagSet.Changed(shell.configFlag)
+	}
+	if path == "" {
+		return nil
+	}
+	if !explicit {
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			return nil
+		}
+	}
+
+	cfg, err := config.Load(path)
+	if err != nil {
+		return err
+	}
+	return flagSet.SetConfig(cfg)
+}
+
 // Options will apply the supplied options to the shell.
 //
 // Options should be called before adding middleware, groups, or handlers.
@@ -102,6 +237,12 @@ func (shell *Shell) Use(middleware ...Middleware) {
 	shell.router.Use(middleware...)
 }
 
+// With adds a new inline-router, using the specified middleware, to the router stack.
+func (shell *Shell) With(middleware ...Middleware) Router {
+	shell.setup()
+	return shell.router.With(middleware...)
+}
+
 // Flags adds a FlagHandler that will add flags to the request FlagSet before
 // it attempts to match a command.
 func (shell *Shell) Flags(fn flags.FlagHandler) {
@@ -121,6 +262,13 @@ func (shell *Shell) Route(command string, fn func(r Router)) Router {
 	return shell.router.Route(command, fn)
 }
 
+// LazyRoute adds a new sub-router to the router stack, along the specified command path,
+// the setup function will not be executed until the sub-router is first evaluated.
+func (shell *Shell) LazyRoute(command string, fn func(r Router)) {
+	shell.setup()
+	shell.router.LazyRoute(command, fn)
+}
+
 // Handle adds a shell handler to the router stack, along the specified command path.
 func (shell *Shell) Handle(command string, handler Handler) {
 	shell.setup()
@@ -139,6 +287,13 @@ func (shell *Shell) NotFound(handler Handler) {
 	shell.router.NotFound(handler)
 }
 
+// Plugins defines a plugin handler that will be used to execute external plugin
+// executables if a command path cannot be evaluated.
+func (shell *Shell) Plugins(handler *PluginHandler) {
+	shell.setup()
+	shell.router.(PluginRouter).Plugins(handler)
+}
+
 // Execute is used to execute the shell, using os.Args to evaluate which function to execute.
 func (shell *Shell) Execute(ctx context.Context) error {
 	shell.setup()
@@ -148,9 +303,15 @@ func (shell *Shell) Execute(ctx context.Context) error {
 // Start is used to begin a new shell session.
 //
 // The interactive shell will read input and evaluate the commands to execute handler functions.
+// The flags are reset to their initial state after each command, unless the sticky flags option
+// has been used, in which case the setflag command can be used to set flags for the entire session.
 func (shell *Shell) Start(ctx context.Context) error {
 	shell.setup()
-	reader := bufio.NewReader(shell.reader)
+	shell.flagSet.Snapshot()
+	// the buffered reader replaces the shell input so that handlers
+	// reading from the request input do not lose any buffered data
+	reader := &bufferedInput{Reader: bufio.NewReader(shell.reader), source: shell.reader}
+	shell.reader = reader
 
 	line := make(chan string)
 	for {
@@ -176,7 +337,14 @@ func (shell *Shell) Start(ctx context.Context) error {
 			return nil
 		case input := <-line:
 			input = strings.TrimSpace(input)
-			err := shell.execute(ctx, strings.Split(input, " "))
+			args := strings.Split(input, " ")
+			var err error
+			if shell.sticky && args[0] == setFlagCommand {
+				err = shell.setFlag(args[1:])
+			} else {
+				err = shell.execute(ctx, args)
+				shell.flagSet.Reset()
+			}
 			if err != nil {
 				fmt.Fprintf(shell.errorWriter, "%v\n", err)
 				if shell.exitOnError {
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"strings"
 	"syscall"
 	"testing"
@@ -182,6 +183,29 @@ func Test_Shell_execute(t *testing.T) {
 	}
 }
 
+func Test_Shell_PosixFlagSet(t *testing.T) {
+	shell := &Shell{}
+	shell.Options(OptionFlagSet(flags.NewPosixFlagSet()))
+	shell.Flags(flags.FlagHandlerFunction(func(fd flags.FlagDefiner) {
+		fd.Bool("verbose,v", false, "")
+	}))
+	shell.Route("users", func(r Router) {
+		r.Flags(flags.FlagHandlerFunction(func(fd flags.FlagDefiner) {
+			fd.Bool("all,a", false, "")
+			fd.String("role,r", "", "")
+		}))
+		r.HandleFunction("list", func(rw ResponseWriter, r *Request) error {
+			verbose, _ := r.FlagValues().GetBool("verbose")
+			all, _ := r.FlagValues().GetBool("all")
+			role, _ := r.FlagValues().GetString("role")
+			return fmt.Errorf("%v %v %s %v", verbose, all, role, r.Args)
+		})
+	})
+
+	actual := shell.execute(context.Background(), []string{"-v", "users", "-ar", "admin", "list", "--", "-arg"})
+	assert.EqualError(t, actual, "true true admin [-arg]")
+}
+
 func Test_Shell_Options(t *testing.T) {
 
 	panicOption := OptionFunction(func(shell *Shell) error {
@@ -419,6 +443,43 @@ func Test_Shell_Group(t *testing.T) {
 	}
 }
 
+func Test_Shell_With(t *testing.T) {
+	shell := &Shell{}
+	shell.With(MiddlewareFunction(func(next Handler) Handler {
+		return HandlerFunction(func(rw ResponseWriter, r *Request) error {
+			err := next.Execute(rw, r)
+			return fmt.Errorf("%w with middleware", err)
+		})
+	})).HandleFunction("with", func(rw ResponseWriter, r *Request) error {
+		return fmt.Errorf("with")
+	})
+	shell.HandleFunction("without", func(rw ResponseWriter, r *Request) error {
+		return fmt.Errorf("without")
+	})
+
+	actual := shell.execute(context.Background(), []string{"with"})
+	assert.EqualError(t, actual, "with with middleware")
+
+	actual = shell.execute(context.Background(), []string{"without"})
+	assert.EqualError(t, actual, "without")
+}
+
+func Test_Shell_LazyRoute(t *testing.T) {
+	called := false
+	shell := &Shell{}
+	shell.LazyRoute("lazy", func(r Router) {
+		called = true
+		r.HandleFunction("test", func(rw ResponseWriter, r *Request) error {
+			return fmt.Errorf("expected")
+		})
+	})
+	assert.False(t, called)
+
+	actual := shell.execute(context.Background(), []string{"lazy", "test"})
+	assert.True(t, called)
+	assert.EqualError(t, actual, "expected")
+}
+
 func Test_Shell_Route(t *testing.T) {
 
 	validArgs := []string{"valid", "test"}
@@ -771,6 +832,102 @@ func Test_Shell_Start(t *testing.T) {
 	}
 }
 
+func Test_Shell_Start_ResetFlags(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	baseFlagSet := flags.NewDefaultFlagSet()
+	baseFlagSet.Bool("base", false, "")
+	tags := &flags.StringArrayFlag{}
+
+	shell := &Shell{
+		reader:       strings.NewReader("-base -toUpper -tag a show\nshow\nexit\n"),
+		outputWriter: &bytes.Buffer{},
+		errorWriter:  &bytes.Buffer{},
+	}
+	shell.Options(OptionFlagSet(baseFlagSet))
+	shell.Flags(flags.FlagHandlerFunction(func(fd flags.FlagDefiner) {
+		fd.Bool("toUpper", false, "")
+		fd.Var(tags, "tag", "")
+	}))
+
+	results := []string{}
+	shell.HandleFunction("show", func(rw ResponseWriter, r *Request) error {
+		base, _ := r.FlagValues().GetBool("base")
+		toUpper, _ := r.FlagValues().GetBool("toUpper")
+		results = append(results, fmt.Sprintf("base=%v changed=%v toUpper=%v tags=%v", base, r.FlagValues().Changed("base"), toUpper, *tags))
+		return nil
+	})
+	shell.HandleFunction("exit", func(rw ResponseWriter, r *Request) error {
+		cancel()
+		return nil
+	})
+
+	go shell.Start(ctx)
+	<-shell.Closed()
+
+	assert.Equal(t, []string{
+		"base=true changed=true toUpper=true tags=[a]",
+		"base=false changed=false toUpper=false tags=[]",
+	}, results)
+}
+
+func Test_Shell_Start_StickyFlags(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	shell := &Shell{
+		reader:       strings.NewReader("setflag toUpper true\nshow\n-toUpper=false show\nsetflag toUpper\nshow\nexit\n"),
+		outputWriter: &bytes.Buffer{},
+		errorWriter:  &bytes.Buffer{},
+	}
+	shell.Options(OptionStickyFlags(true))
+	shell.Flags(flags.FlagHandlerFunction(func(fd flags.FlagDefiner) {
+		fd.Bool("toUpper", false, "")
+	}))
+
+	results := []string{}
+	shell.HandleFunction("show", func(rw ResponseWriter, r *Request) error {
+		toUpper, _ := r.FlagValues().GetBool("toUpper")
+		results = append(results, fmt.Sprintf("toUpper=%v", toUpper))
+		return nil
+	})
+	shell.HandleFunction("exit", func(rw ResponseWriter, r *Request) error {
+		cancel()
+		return nil
+	})
+
+	go shell.Start(ctx)
+	<-shell.Closed()
+
+	assert.Equal(t, []string{"toUpper=true", "toUpper=false", "toUpper=false"}, results)
+}
+
+func Test_Shell_setFlag(t *testing.T) {
+	outputWriter := &bytes.Buffer{}
+	shell := &Shell{
+		outputWriter: outputWriter,
+	}
+	shell.Options(OptionConfigFlag("config"))
+	shell.Flags(flags.FlagHandlerFunction(func(fd flags.FlagDefiner) {
+		fd.Int("count", 0, "")
+		fd.Secret("token", "")
+	}))
+	shell.flagSet.Snapshot()
+
+	assert.Nil(t, shell.setFlag([]string{"count", "2"}))
+	assert.Nil(t, shell.setFlag([]string{"config", "test.json"}))
+	assert.Nil(t, shell.setFlag([]string{"token", "s3cret"}))
+	assert.EqualError(t, shell.setFlag([]string{"count", "two"}), "flagset set failed parse error")
+	assert.EqualError(t, shell.setFlag([]string{"missing", "1"}), "flagset set failed no such flag -missing")
+	assert.EqualError(t, shell.setFlag([]string{"count", "1", "2"}), "flagset set failed usage: setflag [name [value]]")
+
+	assert.Nil(t, shell.setFlag([]string{}))
+	assert.Equal(t, "config=test.json\ncount=2\ntoken=********\n", outputWriter.String())
+
+	assert.Nil(t, shell.setFlag([]string{"config"}))
+	assert.Nil(t, shell.setFlag([]string{"token"}))
+	assert.Equal(t, map[string]string{"count": "2"}, shell.stickyFlags)
+}
+
 func Test_Shell_ExitOnError(t *testing.T) {
 
 	t.Run("default", func(t *testing.T) {
@@ -914,3 +1071,224 @@ func Test_Shell_HelpFallback(t *testing.T) {
 		assert.EqualError(t, actual, "help handler was called")
 	})
 }
+
+func Test_Shell_ValidationFailed(t *testing.T) {
+
+	shell := &Shell{}
+	shell.Flags(flags.FlagHandlerFunction(func(fd flags.FlagDefiner) {
+		fd.String("name", "", "")
+		fd.Required("name")
+	}))
+	shell.HandleFunction("ping", func(ResponseWriter, *Request) error {
+		return nil
+	})
+
+	t.Run("without help handler", func(t *testing.T) {
+		actual := shell.execute(context.Background(), []string{"ping"})
+		assert.True(t, errors.IsFlagsetValidationFailed(actual))
+		assert.EqualError(t, actual, "flagset validation failed: -name is required")
+	})
+
+	t.Run("with help handler", func(t *testing.T) {
+		called := false
+		shell.helpHandler = HandlerFunction(func(rw ResponseWriter, r *Request) error {
+			called = true
+			assert.Equal(t, []string{"ping"}, r.Args)
+			return nil
+		})
+		defer func() {
+			shell.helpHandler = nil
+		}()
+
+		actual := shell.execute(context.Background(), []string{"ping"})
+		assert.True(t, called)
+		assert.EqualError(t, actual, "flagset validation failed: -name is required")
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		actual := shell.execute(context.Background(), []string{"-name", "bob", "ping"})
+		assert.Nil(t, actual)
+	})
+}
+
+func Test_Shell_EnvPrefix(t *testing.T) {
+	t.Setenv("MYCLI_USER_NAME", "env")
+
+	shell := &Shell{}
+	shell.Options(OptionEnvPrefix("MYCLI_"))
+	shell.Flags(flags.FlagHandlerFunction(func(fd flags.FlagDefiner) {
+		fd.String("user-name", "default", "")
+	}))
+	shell.HandleFunction("whoami", func(rw ResponseWriter, r *Request) error {
+		name, _ := r.FlagValues().GetString("user-name")
+		return fmt.Errorf("%s %s", name, r.FlagValues().Source("user-name"))
+	})
+
+	actual := shell.execute(context.Background(), []string{"whoami"})
+	assert.EqualError(t, actual, "env environment")
+
+	actual = shell.execute(context.Background(), []string{"-user-name", "cli", "whoami"})
+	assert.EqualError(t, actual, "cli command-line")
+}
+
+func Test_Shell_Changed(t *testing.T) {
+	shell := &Shell{}
+	shell.Flags(flags.FlagHandlerFunction(func(fd flags.FlagDefiner) {
+		fd.String("suffix", "", "")
+	}))
+	shell.Route("users", func(r Router) {
+		r.Flags(flags.FlagHandlerFunction(func(fd flags.FlagDefiner) {
+			fd.String("role", "viewer", "")
+		}))
+		r.HandleFunction("add", func(rw ResponseWriter, r *Request) error {
+			return fmt.Errorf("suffix=%v role=%v", r.FlagValues().Changed("suffix"), r.FlagValues().Changed("role"))
+		})
+	})
+
+	actual := shell.execute(context.Background(), []string{"users", "add"})
+	assert.EqualError(t, actual, "suffix=false role=false")
+
+	actual = shell.execute(context.Background(), []string{"-suffix", "", "users", "-role", "viewer", "add"})
+	assert.EqualError(t, actual, "suffix=true role=true")
+}
+
+func Test_Shell_DeprecatedFlag(t *testing.T) {
+	errorWriter := &bytes.Buffer{}
+	shell := &Shell{}
+	shell.Options(OptionErrorWriter(errorWriter))
+	shell.Flags(flags.FlagHandlerFunction(func(fd flags.FlagDefiner) {
+		fd.Bool("debug-trace", false, "")
+		fd.Hidden("debug-trace")
+		fd.Bool("quiet", false, "")
+		fd.Deprecated("quiet", "use -verbose=false instead")
+	}))
+	shell.HandleFunction("ping", func(rw ResponseWriter, r *Request) error {
+		trace, _ := r.FlagValues().GetBool("debug-trace")
+		return fmt.Errorf("trace=%v", trace)
+	})
+
+	actual := shell.execute(context.Background(), []string{"-debug-trace", "ping"})
+	assert.EqualError(t, actual, "trace=true")
+	assert.Empty(t, errorWriter.String())
+
+	actual = shell.execute(context.Background(), []string{"-quiet", "ping"})
+	assert.EqualError(t, actual, "trace=false")
+	assert.Equal(t, "Flag \"-quiet\" is deprecated, use -verbose=false instead\n", errorWriter.String())
+}
+
+func Test_Shell_FileValue(t *testing.T) {
+	shell := &Shell{}
+	shell.Options(OptionInput(strings.NewReader("s3cret\n")))
+	shell.Flags(flags.FlagHandlerFunction(func(fd flags.FlagDefiner) {
+		fd.String("password", "", "")
+		fd.FileValue("password")
+	}))
+	shell.HandleFunction("login", func(rw ResponseWriter, r *Request) error {
+		password, _ := r.FlagValues().GetString("password")
+		return fmt.Errorf("password=%s", password)
+	})
+
+	actual := shell.execute(context.Background(), []string{"-password", "@-", "login"})
+	assert.EqualError(t, actual, "password=s3cret")
+}
+
+func Test_Shell_Start_FileValue(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	shell := &Shell{
+		reader:       strings.NewReader("-password @- login\ns3cret\nshow\nexit\n"),
+		outputWriter: &bytes.Buffer{},
+		errorWriter:  &bytes.Buffer{},
+	}
+	shell.Flags(flags.FlagHandlerFunction(func(fd flags.FlagDefiner) {
+		fd.String("password", "", "")
+		fd.FileValue("password")
+	}))
+
+	results := []string{}
+	shell.HandleFunction("login", func(rw ResponseWriter, r *Request) error {
+		password, _ := r.FlagValues().GetString("password")
+		results = append(results, fmt.Sprintf("password=%s", password))
+		return nil
+	})
+	shell.HandleFunction("show", func(rw ResponseWriter, r *Request) error {
+		results = append(results, "show")
+		return nil
+	})
+	shell.HandleFunction("exit", func(rw ResponseWriter, r *Request) error {
+		cancel()
+		return nil
+	})
+
+	go shell.Start(ctx)
+	<-shell.Closed()
+
+	assert.Equal(t, []string{"password=s3cret", "show"}, results)
+}
+
+func Test_Shell_Config(t *testing.T) {
+	dir := t.TempDir()
+	defaultPath := filepath.Join(dir, "default.json")
+	assert.Nil(t, os.WriteFile(defaultPath, []byte(`{"verbose": true, "users": {"add": {"role": "admin"}}}`), 0600))
+	otherPath := filepath.Join(dir, "other.ini")
+	assert.Nil(t, os.WriteFile(otherPath, []byte("[users]\nrole = editor\n"), 0600))
+
+	newShell := func(options ...Option) *Shell {
+		shell := &Shell{}
+		shell.Options(options...)
+		shell.Flags(flags.FlagHandlerFunction(func(fd flags.FlagDefiner) {
+			fd.Bool("verbose", false, "")
+		}))
+		shell.Route("users", func(r Router) {
+			r.Flags(flags.FlagHandlerFunction(func(fd flags.FlagDefiner) {
+				fd.String("role", "viewer", "")
+			}))
+			r.HandleFunction("add", func(rw ResponseWriter, r *Request) error {
+				verbose, _ := r.FlagValues().GetBool("verbose")
+				role, _ := r.FlagValues().GetString("role")
+				return fmt.Errorf("verbose=%v role=%s", verbose, role)
+			})
+		})
+		return shell
+	}
+
+	t.Run("no config", func(t *testing.T) {
+		actual := newShell().execute(context.Background(), []string{"users", "add"})
+		assert.EqualError(t, actual, "verbose=false role=viewer")
+	})
+
+	t.Run("config file", func(t *testing.T) {
+		actual := newShell(OptionConfigFile(defaultPath)).execute(context.Background(), []string{"users", "add"})
+		assert.EqualError(t, actual, "verbose=true role=admin")
+	})
+
+	t.Run("config flag default", func(t *testing.T) {
+		shell := newShell(OptionConfigFile(defaultPath), OptionConfigFlag("config"))
+		actual := shell.execute(context.Background(), []string{"users", "add", "-role", "owner"})
+		assert.EqualError(t, actual, "verbose=true role=owner")
+	})
+
+	t.Run("config flag", func(t *testing.T) {
+		shell := newShell(OptionConfigFile(defaultPath), OptionConfigFlag("config"))
+		actual := shell.execute(context.Background(), []string{"-config", otherPath, "users", "add"})
+		assert.EqualError(t, actual, "verbose=false role=editor")
+	})
+
+	t.Run("invalid file", func(t *testing.T) {
+		shell := newShell(OptionConfigFlag("config"))
+		actual := shell.execute(context.Background(), []string{"-config", filepath.Join(dir, "missing.json"), "users", "add"})
+		assert.True(t, errors.IsConfigLoadFailed(actual))
+	})
+
+	t.Run("missing default file", func(t *testing.T) {
+		missingPath := filepath.Join(dir, "missing.json")
+		actual := newShell(OptionConfigFile(missingPath)).execute(context.Background(), []string{"users", "add"})
+		assert.EqualError(t, actual, "verbose=false role=viewer")
+
+		actual = newShell(OptionConfigFile(missingPath), OptionConfigFlag("config")).execute(context.Background(), []string{"users", "add"})
+		assert.EqualError(t, actual, "verbose=false role=viewer")
+
+		actual = newShell(OptionConfigFile(defaultPath), OptionConfigFlag("config")).execute(context.Background(), []string{"-config", missingPath, "users", "add"})
+		assert.True(t, errors.IsConfigLoadFailed(actual))
+	})
+}
@@ -0,0 +1,105 @@
+package shell
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// bufferedInput is the buffered shell input used by an interactive shell, which
+// retains the original input so it can be determined if the input is a terminal.
+type bufferedInput struct {
+	*bufio.Reader
+	source io.Reader
+}
+
+// lineInput reads a single line from the buffered shell input, so that a flag value read
+// from the input using @- does not consume the commands that follow in an interactive shell.
+type lineInput struct {
+	input *bufferedInput
+	done  bool
+}
+
+func (line *lineInput) Read(p []byte) (int, error) {
+	if line.done {
+		return 0, io.EOF
+	}
+	n := 0
+	for n < len(p) {
+		b, err := line.input.ReadByte()
+		if err != nil {
+			if n > 0 {
+				return n, nil
+			}
+			return 0, err
+		}
+		p[n] = b
+		n++
+		if b == '\n' {
+			line.done = true
+			break
+		}
+	}
+	return n, nil
+}
+
+// terminalFile returns the file for the input if the input is a terminal.
+func terminalFile(input io.Reader) (*os.File, bool) {
+	if buffered, ok := input.(*bufferedInput); ok {
+		input = buffered.source
+	}
+	file, ok := input.(*os.File)
+	if !ok || !term.IsTerminal(int(file.Fd())) {
+		return nil, false
+	}
+	return file, true
+}
+
+// readLine reads a single line from the input, excluding the line ending.
+func readLine(input io.Reader) (string, error) {
+	if reader, ok := input.(interface {
+		ReadString(delim byte) (string, error)
+	}); ok {
+		line, err := reader.ReadString('\n')
+		if err != nil && (err != io.EOF || line == "") {
+			return "", err
+		}
+		return strings.TrimRight(line, "\r\n"), nil
+	}
+
+	// inputs that are not buffered are read a byte at a time
+	// so no input following the line is consumed
+	line := []byte{}
+	buffer := make([]byte, 1)
+	for {
+		n, err := input.Read(buffer)
+		if n > 0 {
+			if buffer[0] == '\n' {
+				break
+			}
+			line = append(line, buffer[0])
+		}
+		if err != nil {
+			if err != io.EOF || len(line) == 0 {
+				return "", err
+			}
+			break
+		}
+	}
+	return strings.TrimRight(string(line), "\r"), nil
+}
+
+// readSecret reads a single line from the terminal without echoing it.
+//
+// The secret is read from the terminal directly, rather than the buffered shell input,
+// as the buffered input will not contain any text typed after the prompt was written.
+func readSecret(file *os.File) (string, error) {
+	secret, err := term.ReadPassword(int(file.Fd()))
+	if err != nil {
+		return "", err
+	}
+	return string(secret), nil
+}
@@ -0,0 +1,59 @@
+package shell
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_terminalFile(t *testing.T) {
+	_, ok := terminalFile(strings.NewReader(""))
+	assert.False(t, ok)
+
+	reader, writer, err := os.Pipe()
+	assert.Nil(t, err)
+	defer reader.Close()
+	defer writer.Close()
+
+	_, ok = terminalFile(reader)
+	assert.False(t, ok)
+	_, ok = terminalFile(&bufferedInput{Reader: bufio.NewReader(reader), source: reader})
+	assert.False(t, ok)
+}
+
+func Test_readLine(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		buffered bool
+	}{
+		{name: "unbuffered"},
+		{name: "buffered", buffered: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			input := strings.NewReader("first\r\nsecond\nlast")
+			var reader io.Reader = input
+			if test.buffered {
+				reader = bufio.NewReader(input)
+			}
+
+			line, err := readLine(reader)
+			assert.Nil(t, err)
+			assert.Equal(t, "first", line)
+			line, err = readLine(reader)
+			assert.Nil(t, err)
+			assert.Equal(t, "second", line)
+			line, err = readLine(reader)
+			assert.Nil(t, err)
+			assert.Equal(t, "last", line)
+			_, err = readLine(reader)
+			assert.NotNil(t, err)
+		})
+	}
+}
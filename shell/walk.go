@@ -0,0 +1,63 @@
+package shell
+
+import "sort"
+
+// WalkFunc is the type of the function called by Walk for each handler and router visited.
+//
+// The path contains the full command path used to reach the handler and the
+// middlewares contain the effective middleware chain that will wrap the handler.
+type WalkFunc func(path []string, handler Handler, middlewares []Middleware) error
+
+// Walk traverses the routing tree, calling walkFn for every handler and router
+// in the tree, including those defined in inline-routers, sub-routers, and mounted routers.
+// Inline-routers are only visited for routers that implement the GroupRoutes interface.
+//
+// Handlers are visited in command name order, and the traversal will stop if
+// walkFn returns an error.
+func Walk(routes Routes, walkFn WalkFunc) error {
+	return walk(routes, walkFn, []string{}, []Middleware{})
+}
+
+func walk(routes Routes, walkFn WalkFunc, parentPath []string, parentMiddlewares []Middleware) error {
+	middlewares := make([]Middleware, 0, len(parentMiddlewares)+len(routes.Middlewares()))
+	middlewares = append(middlewares, parentMiddlewares...)
+	middlewares = append(middlewares, routes.Middlewares()...)
+
+	handlers := routes.Routes()
+	keys := make([]string, 0, len(handlers))
+	for key := range handlers {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		handler := handlers[key]
+
+		path := make([]string, len(parentPath), len(parentPath)+1)
+		copy(path, parentPath)
+		path = append(path, key)
+
+		chain := make([]Middleware, len(middlewares))
+		copy(chain, middlewares)
+
+		if err := walkFn(path, handler, chain); err != nil {
+			return err
+		}
+
+		if subRoutes, ok := handler.(Routes); ok {
+			if err := walk(subRoutes, walkFn, path, middlewares); err != nil {
+				return err
+			}
+		}
+	}
+
+	if groupRoutes, ok := routes.(GroupRoutes); ok {
+		for _, group := range groupRoutes.Groups() {
+			if err := walk(group, walkFn, parentPath, middlewares); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,77 @@
+package shell
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Walk(t *testing.T) {
+
+	noop := MiddlewareFunction(func(next Handler) Handler { return next })
+	handlerFunction := func(ResponseWriter, *Request) error { return nil }
+
+	mounted := &StandardRouter{}
+	mounted.Use(noop)
+	mounted.HandleFunction("inner", handlerFunction)
+
+	router := &StandardRouter{}
+	router.Use(noop)
+	router.HandleFunction("ping", handlerFunction)
+	router.Route("users", func(r Router) {
+		r.Use(noop)
+		r.HandleFunction("list", handlerFunction)
+		r.HandleFunction("add", handlerFunction)
+	})
+	router.Group(func(r Router) {
+		r.Use(noop, noop)
+		r.HandleFunction("group", handlerFunction)
+	})
+	router.Mount("mounted", mounted)
+
+	t.Run("visit all", func(t *testing.T) {
+		actual := []string{}
+		err := Walk(router, func(path []string, handler Handler, middlewares []Middleware) error {
+			actual = append(actual, fmt.Sprintf("%s:%d", strings.Join(path, " "), len(middlewares)))
+			return nil
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, []string{
+			"mounted:1",
+			"mounted inner:2",
+			"ping:1",
+			"users:1",
+			"users add:2",
+			"users list:2",
+			"group:3",
+		}, actual)
+	})
+
+	t.Run("stop on error", func(t *testing.T) {
+		count := 0
+		err := Walk(router, func(path []string, handler Handler, middlewares []Middleware) error {
+			count++
+			if path[len(path)-1] == "ping" {
+				return fmt.Errorf("stopped")
+			}
+			return nil
+		})
+		assert.Equal(t, fmt.Errorf("stopped"), err)
+		assert.Equal(t, 3, count)
+	})
+
+	t.Run("stop on nested error", func(t *testing.T) {
+		count := 0
+		err := Walk(router, func(path []string, handler Handler, middlewares []Middleware) error {
+			count++
+			if len(path) > 1 {
+				return fmt.Errorf("nested")
+			}
+			return nil
+		})
+		assert.Equal(t, fmt.Errorf("nested"), err)
+		assert.Equal(t, 2, count)
+	})
+}